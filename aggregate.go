@@ -0,0 +1,225 @@
+package goddb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// parent struct 의 child slice 필드 (gdrm:"children,sk=ORDER#,limit=20,desc")
+type aggregateChild struct {
+	index    []int
+	name     string
+	skPrefix string
+	limit    int  // 0 이면 제한 없음
+	desc     bool // SK 역순
+}
+
+func aggregateChildren(t reflect.Type) ([]aggregateChild, error) {
+
+	var children []aggregateChild
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup("gdrm")
+		if !ok {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		if options[0] != "children" {
+			continue
+		}
+
+		if !field.IsExported() || field.Type.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("children field %s.%s must be an exported slice", t, field.Name)
+		}
+
+		child := aggregateChild{index: field.Index, name: field.Name}
+		for _, option := range options[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+
+			switch name {
+			case "sk":
+				child.skPrefix = value
+			case "limit":
+				limit, err := strconv.Atoi(value)
+				if err != nil || limit < 0 {
+					return nil, fmt.Errorf("children field %s.%s: invalid limit %q", t, field.Name, value)
+				}
+				child.limit = limit
+			case "desc":
+				child.desc = true
+			case "asc":
+				child.desc = false
+			default:
+				return nil, fmt.Errorf("children field %s.%s: unknown option %q", t, field.Name, option)
+			}
+		}
+
+		if child.skPrefix == "" {
+			return nil, fmt.Errorf("children field %s.%s needs sk prefix", t, field.Name)
+		}
+		children = append(children, child)
+	}
+
+	return children, nil
+}
+
+// 가장 긴 SK prefix 가 일치하는 child (없으면 -1)
+func routeChild(children []aggregateChild, sk string) int {
+	found := -1
+	for i, child := range children {
+		if !strings.HasPrefix(sk, child.skPrefix) {
+			continue
+		}
+		if found < 0 || len(child.skPrefix) > len(children[found].skPrefix) {
+			found = i
+		}
+	}
+
+	return found
+}
+
+// pk 파티션을 한번의 Query (page 단위) 로 읽어서 SK = sk 인 item 은 T 로, 나머지는 가장 긴 SK prefix 가 일치하는 child slice 로 변환
+// parent 가 없으면 ErrNotFound, prefix 가 없는 item 은 무시
+// limit 은 child 별 최대 개수 (desc 면 SK 가 큰 쪽부터)
+//
+//	type UserWithOrders struct {
+//		User
+//		Orders []Order `dynamodbav:"-" gdrm:"children,sk=ORDER#,limit=20,desc"`
+//	}
+//
+//	user, err := LoadAggregate[UserWithOrders](ctx, client, "my_table", "USER#123", "#PROFILE")
+func LoadAggregate[T any](ctx context.Context, c *DDBClient, tableName, pk, sk string) (T, error) {
+
+	var result T
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return result, fmt.Errorf("aggregate type %s must be a struct", t)
+	}
+
+	children, err := aggregateChildren(t)
+	if err != nil {
+		return result, err
+	}
+
+	names := c.keyAttributes(tableName)
+	if names.sk == "" {
+		return result, fmt.Errorf("table %s has no sort key", tableName)
+	}
+
+	parent, childItems, err := c.aggregateItems(ctx, tableName, names, pk, sk, children)
+	if err != nil {
+		return result, err
+	}
+
+	if parent == nil {
+		return result, ErrNotFound
+	}
+
+	if err := decodeInto(0, parent, c.decodeOptions(tableName), reflect.ValueOf(&result)); err != nil {
+		return result, err
+	}
+
+	v := reflect.ValueOf(&result).Elem()
+	for i, child := range children {
+		field, err := v.FieldByIndexErr(child.index)
+		if err != nil {
+			return result, fmt.Errorf("children %s: %w", child.name, err)
+		}
+		elem := field.Type().Elem()
+
+		values := reflect.MakeSlice(field.Type(), 0, len(childItems[i]))
+		for j, item := range childItems[i] {
+			out := reflect.New(elem)
+			if err := decodeInto(j, item, c.decodeOptions(tableName), out); err != nil {
+				return result, fmt.Errorf("children %s: %w", child.name, err)
+			}
+			values = reflect.Append(values, out.Elem())
+		}
+		field.Set(values)
+	}
+
+	return result, nil
+}
+
+// #pk = :pk Query 로 파티션을 읽으면서 parent 와 child 별 item 으로 나눔 (child 는 limit 개만 유지)
+func (c DDBClient) aggregateItems(ctx context.Context, tableName string, names keyAttributes, pk, sk string, children []aggregateChild) (map[string]types.AttributeValue, [][]map[string]types.AttributeValue, error) {
+
+	pkValue, err := keyValue(tableName, names.pk, c.tables[tableName].PkAttributeType, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := RangeParams{
+		KeyConditionExpression:    "#pk = :pk",
+		ExpressionAttributeNames:  map[string]string{"#pk": names.pk},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": pkValue},
+	}
+
+	var parent map[string]types.AttributeValue
+	childItems := make([][]map[string]types.AttributeValue, len(children))
+	var startKey map[string]types.AttributeValue
+
+	for {
+		res, err := c.query(ctx, tableName, 0, startKey, params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// SK 오름차순
+		for _, item := range res.Items {
+			itemSK, ok := item[names.sk].(*types.AttributeValueMemberS)
+			if !ok {
+				continue
+			}
+
+			if itemSK.Value == sk {
+				parent = item
+				continue
+			}
+
+			i := routeChild(children, itemSK.Value)
+			if i < 0 {
+				continue
+			}
+
+			child := children[i]
+			switch {
+			case child.limit == 0:
+				childItems[i] = append(childItems[i], item)
+			case !child.desc:
+				if len(childItems[i]) < child.limit {
+					childItems[i] = append(childItems[i], item)
+				}
+			default:
+				// desc 는 마지막 limit 개만 유지
+				childItems[i] = append(childItems[i], item)
+				if len(childItems[i]) > child.limit {
+					childItems[i] = childItems[i][1:]
+				}
+			}
+		}
+
+		startKey = res.LastEvaluatedKey
+		if len(startKey) == 0 {
+			break
+		}
+	}
+
+	for i, child := range children {
+		if childItems[i] == nil {
+			childItems[i] = []map[string]types.AttributeValue{}
+		}
+		if child.desc {
+			slices.Reverse(childItems[i])
+		}
+	}
+
+	return parent, childItems, nil
+}
@@ -0,0 +1,104 @@
+package goddb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gookit/assert"
+)
+
+type UserAggregate struct {
+	Message
+	Orders  []Order       `dynamodbav:"-" gdrm:"children,sk=ORDER#,limit=3,desc"`
+	Teams   []*TeamMember `dynamodbav:"-" gdrm:"children,sk=TEAM#"`
+	Archive []Order       `dynamodbav:"-" gdrm:"children,sk=ORDER#ARCHIVE#"`
+}
+
+func Test_DDBAggregate(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	scenarioInsertOrders(t, "user_logs_1", 5)
+
+	items := []any{
+		Message{PK: "USER#1", SK: "#PROFILE", Name: "leedonggyu"},
+		Message{PK: "USER#2", SK: "#PROFILE", Name: "other"},
+		TeamMember{PK: "USER#1", SK: "TEAM#devops", Role: "owner"},
+		TeamMember{PK: "USER#1", SK: "TEAM#platform", Role: "member"},
+		Order{PK: "USER#1", SK: "ORDER#ARCHIVE#001", Amount: 1},
+		Order{PK: "USER#1", SK: "PAYMENT#001"},
+	}
+	assert.NoError(t, client.InsertBatch(ctx, "user_logs_1", items))
+
+	t.Run("1. parent + children 조회", func(t *testing.T) {
+		user, err := LoadAggregate[UserAggregate](ctx, client, "user_logs_1", "USER#1", "#PROFILE")
+		assert.NoError(t, err)
+		assert.Eq(t, user.Name, "leedonggyu")
+
+		// 최신 3 개 (SK 역순)
+		assert.Eq(t, len(user.Orders), 3)
+		for i, order := range user.Orders {
+			assert.Eq(t, order.SK, fmt.Sprintf("ORDER#%03d", 5-i))
+		}
+
+		assert.Eq(t, len(user.Teams), 2)
+		assert.Eq(t, user.Teams[0].Role, "owner")
+
+		// 더 긴 prefix 가 우선
+		assert.Eq(t, user.Archive, []Order{{PK: "USER#1", SK: "ORDER#ARCHIVE#001", Amount: 1}})
+	})
+
+	t.Run("2. child 가 없는 parent", func(t *testing.T) {
+		user, err := LoadAggregate[UserAggregate](ctx, client, "user_logs_1", "USER#2", "#PROFILE")
+		assert.NoError(t, err)
+		assert.Eq(t, user.Name, "other")
+		assert.Eq(t, len(user.Orders), 0)
+	})
+
+	t.Run("3. parent 가 없으면 ErrNotFound", func(t *testing.T) {
+		_, err := LoadAggregate[UserAggregate](ctx, client, "user_logs_1", "USER#3", "#PROFILE")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("4. 파티션을 한번의 Query 로 조회", func(t *testing.T) {
+		db := &countingDB{DB: ddbClient}
+		counted := NewDDB(db)
+
+		type latestOrders struct {
+			Message
+			Orders []Order `dynamodbav:"-" gdrm:"children,sk=ORDER#,limit=2,desc"`
+		}
+
+		user, err := LoadAggregate[latestOrders](ctx, counted, "user_logs_1", "USER#1", "#PROFILE")
+		assert.NoError(t, err)
+		assert.Eq(t, user.Orders[0].SK, "ORDER#ARCHIVE#001")
+		assert.Eq(t, user.Orders[1].SK, "ORDER#005")
+
+		// parent 조회 없이 partition Query 한번
+		assert.Eq(t, db.queryCalls, 1)
+		assert.Len(t, user.Orders, 2)
+	})
+
+	t.Run("5. 잘못된 children tag", func(t *testing.T) {
+		type noPrefix struct {
+			Orders []Order `gdrm:"children"`
+		}
+		_, err := LoadAggregate[noPrefix](ctx, client, "user_logs_1", "USER#1", "#PROFILE")
+		assert.Err(t, err)
+
+		type notSlice struct {
+			Order Order `gdrm:"children,sk=ORDER#"`
+		}
+		_, err = LoadAggregate[notSlice](ctx, client, "user_logs_1", "USER#1", "#PROFILE")
+		assert.Err(t, err)
+
+		type badLimit struct {
+			Orders []Order `gdrm:"children,sk=ORDER#,limit=-1"`
+		}
+		_, err = LoadAggregate[badLimit](ctx, client, "user_logs_1", "USER#1", "#PROFILE")
+		assert.Err(t, err)
+	})
+}
@@ -2,6 +2,9 @@ package goddb
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -14,18 +17,74 @@ const (
 	ERROR = "error"
 )
 
+const (
+	TABLE_WAIT_INTERVAL = 5 * time.Second // 테이블이 ACTIVE 인지 확인하는 간격
+)
+
+// DDBTableParams 에 key 이름이 없을때 사용하는 기본 hash / range attribute
 const (
 	PrimaryKey = "PK"
 	SortKey    = "SK"
 )
 
-func NewDDB(dynamoDBClient *dynamodb.Client) *DDBClient {
+// 테이블의 hash / range attribute 이름
+type keyAttributes struct {
+	pk string
+	sk string // hash key 만 있는 테이블이면 ""
+}
+
+func (params DDBTableParams) keyAttributes() keyAttributes {
+	names := keyAttributes{pk: PrimaryKey, sk: SortKey}
+
+	if params.PkAttributeName != "" {
+		names.pk = params.PkAttributeName
+	}
+	if params.SkAttributeName != "" {
+		names.sk = params.SkAttributeName
+	}
+
+	return names
+}
+
+// 등록되지 않은 테이블은 PK / SK, IsSK 가 없는 테이블은 hash key 만 사용
+func (c DDBClient) keyAttributes(tableName string) keyAttributes {
+	params, ok := c.tables[tableName]
+
+	names := params.keyAttributes()
+	if ok && !params.IsSK {
+		names.sk = ""
+	}
+
+	return names
+}
+
+// *dynamodb.Client 또는 DynamoAPI 를 구현한 client 를 받음
+func NewDDB(dynamoDBClient DynamoAPI) *DDBClient {
 	return &DDBClient{
-		client: dynamoDBClient,
-		tables: map[string]DDBTableParams{},
+		client:   newThrottledClient(dynamoDBClient),
+		tables:   map[string]DDBTableParams{},
+		entities: map[reflect.Type]entityType{},
 	}
 }
 
+// Get / Query / FindSeq / ScanSeq 에서 struct 에 없는 attribute 가 있으면 에러
+func (c *DDBClient) WithStrictDecode(strict bool) *DDBClient {
+	c.strict = strict
+	return c
+}
+
+// Start 에서 이미 있는 테이블을 처리하는 방법 (기본 ReconcileVerify)
+func (c *DDBClient) WithReconcileMode(mode ReconcileMode) *DDBClient {
+	c.reconcile = mode
+	return c
+}
+
+// ReconcileUpdate 에서 UpdateTable 을 모두 적용하고 ACTIVE 까지 대기 (기본은 Start 마다 하나씩 요청만 하고 반환)
+func (c *DDBClient) WithReconcileWait(wait bool) *DDBClient {
+	c.reconcileWait = wait
+	return c
+}
+
 func (c *DDBClient) AddTable(tableName string, table DDBTableParams) *DDBClient {
 
 	c.tables[tableName] = table
@@ -39,52 +98,164 @@ func (c *DDBClient) Start(ctx context.Context, isCreateTable bool) error {
 		"isCreate":         isCreateTable,
 	})
 
+	// 아직 반영중인 테이블 (나머지 테이블은 계속 처리)
+	var pending []error
+
 	for tableName, params := range c.tables {
 
-		if params.IsCreate {
+		if !params.IsCreate {
+			continue
+		}
 
-			c.trace(INFO, "DDBClient.Start.CreateTable.GetPKandSK", map[string]any{
-				"tableName": tableName,
+		// 이미 있는 테이블은 reconcile mode 에 따라 처리
+		if c.reconcile != ReconcileFail {
+			output, err := c.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+				TableName: aws.String(tableName),
 			})
 
-			keySchema, keyAttribute := getPKandSK(params)
-
-			createTableInput := &dynamodb.CreateTableInput{
-				TableName:            aws.String(tableName),
-				KeySchema:            keySchema,
-				AttributeDefinitions: keyAttribute,
-			}
-
-			// ondemand
-			if params.BillingMode.IsOnDemand {
-				createTableInput.BillingMode = getBillingMode(params.BillingMode)
-			}
-
-			if !params.BillingMode.IsOnDemand {
-				createTableInput.BillingMode = getBillingMode(params.BillingMode)
-				createTableInput.ProvisionedThroughput = &types.ProvisionedThroughput{
-					ReadCapacityUnits:  aws.Int64(int64(params.BillingMode.IsProvisioned.ReadCapacityUnits)),
-					WriteCapacityUnits: aws.Int64(int64(params.BillingMode.IsProvisioned.WriteCapacityUnits)),
+			var notFound *types.ResourceNotFoundException
+			switch {
+			case err == nil:
+				if err := c.reconcileTable(ctx, tableName, params, output.Table); err != nil {
+					if !errors.Is(err, ErrUpdatePending) {
+						return err
+					}
+					pending = append(pending, err)
 				}
-			}
+				continue
 
-			_, err := c.client.CreateTable(ctx, createTableInput)
-
-			if err != nil {
-				c.trace(ERROR, "DDBClient.Start.CreateTable.Error", map[string]any{
+			case !errors.As(err, &notFound):
+				c.trace(ERROR, "DDBClient.Start.DescribeTable.Error", map[string]any{
 					"tableName": tableName,
 					"error":     err,
 				})
 
 				return err
 			}
+		}
+
+		if err := c.createTable(ctx, tableName, params); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(pending...)
+}
+
+func (c DDBClient) createTable(ctx context.Context, tableName string, params DDBTableParams) error {
+
+	c.trace(INFO, "DDBClient.Start.CreateTable.GetPKandSK", map[string]any{
+		"tableName": tableName,
+	})
+
+	keySchema, keyAttribute := getPKandSK(params)
+	globalIndexes, keyAttribute := getGlobalSecondaryIndexes(params, keyAttribute)
+	localIndexes, keyAttribute := getLocalSecondaryIndexes(params, keyAttribute)
+
+	createTableInput := &dynamodb.CreateTableInput{
+		TableName:              aws.String(tableName),
+		KeySchema:              keySchema,
+		AttributeDefinitions:   keyAttribute,
+		GlobalSecondaryIndexes: globalIndexes,
+		LocalSecondaryIndexes:  localIndexes,
+	}
+
+	// ondemand
+	if params.BillingMode.IsOnDemand {
+		createTableInput.BillingMode = getBillingMode(params.BillingMode)
+	}
+
+	if !params.BillingMode.IsOnDemand {
+		createTableInput.BillingMode = getBillingMode(params.BillingMode)
+		createTableInput.ProvisionedThroughput = getProvisionedThroughput(params.BillingMode.IsProvisioned)
+	}
+
+	_, err := c.client.CreateTable(ctx, createTableInput)
+
+	if err != nil {
+		c.trace(ERROR, "DDBClient.Start.CreateTable.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+
+		return err
+	}
+
+	c.trace(INFO, "DDBClient.Start.CreateTable.Success", map[string]any{
+		"tableName": tableName,
+	})
+
+	// CREATING 상태에서는 UpdateTimeToLive 가 거부됨
+	if params.TTLAttributeName != "" {
+		if err := c.waitTableActive(ctx, tableName); err != nil {
+			return err
+		}
+		return c.enableTimeToLive(ctx, tableName, params.TTLAttributeName)
+	}
+
+	return nil
+}
+
+// 테이블과 GSI 가 ACTIVE 가 될 때까지 TABLE_WAIT_INTERVAL 간격으로 확인
+func (c DDBClient) waitTableActive(ctx context.Context, tableName string) error {
 
-			c.trace(INFO, "DDBClient.Start.CreateTable.Success", map[string]any{
+	for {
+		output, err := c.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(tableName),
+		})
+		if err != nil {
+			c.trace(ERROR, "DDBClient.Start.DescribeTable.Error", map[string]any{
 				"tableName": tableName,
+				"error":     err,
 			})
+			return err
+		}
+
+		if tableActive(output.Table) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(TABLE_WAIT_INTERVAL):
+		}
+	}
+}
+
+func tableActive(table *types.TableDescription) bool {
+	if table.TableStatus != types.TableStatusActive {
+		return false
+	}
+
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		if gsi.IndexStatus != types.IndexStatusActive {
+			return false
 		}
 	}
 
+	return true
+}
+
+func (c DDBClient) enableTimeToLive(ctx context.Context, tableName, attributeName string) error {
+
+	_, err := c.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(tableName),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(attributeName),
+			Enabled:       aws.Bool(true),
+		},
+	})
+
+	if err != nil {
+		c.trace(ERROR, "DDBClient.Start.UpdateTimeToLive.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+
+		return err
+	}
+
 	return nil
 }
 
@@ -116,17 +287,18 @@ func (c DDBClient) trace(level, ph string, item map[string]any) {
 func getPKandSK(params DDBTableParams) ([]types.KeySchemaElement, []types.AttributeDefinition) {
 	keySchema := []types.KeySchemaElement{}
 	keyAttribute := []types.AttributeDefinition{}
+	names := params.keyAttributes()
 
 	// use pk
 	if params.IsPK {
 
 		keySchema = append(keySchema, types.KeySchemaElement{
-			AttributeName: aws.String(PrimaryKey),
+			AttributeName: aws.String(names.pk),
 			KeyType:       types.KeyTypeHash,
 		})
 
 		keyAttribute = append(keyAttribute, types.AttributeDefinition{
-			AttributeName: aws.String(PrimaryKey),
+			AttributeName: aws.String(names.pk),
 			AttributeType: params.PkAttributeType,
 		})
 
@@ -136,12 +308,12 @@ func getPKandSK(params DDBTableParams) ([]types.KeySchemaElement, []types.Attrib
 	if params.IsSK {
 
 		keySchema = append(keySchema, types.KeySchemaElement{
-			AttributeName: aws.String(SortKey),
+			AttributeName: aws.String(names.sk),
 			KeyType:       types.KeyTypeRange,
 		})
 
 		keyAttribute = append(keyAttribute, types.AttributeDefinition{
-			AttributeName: aws.String(SortKey),
+			AttributeName: aws.String(names.sk),
 			AttributeType: params.SkAttributeType,
 		})
 
@@ -150,6 +322,100 @@ func getPKandSK(params DDBTableParams) ([]types.KeySchemaElement, []types.Attrib
 	return keySchema, keyAttribute
 }
 
+func getGlobalSecondaryIndexes(params DDBTableParams, keyAttribute []types.AttributeDefinition) ([]types.GlobalSecondaryIndex, []types.AttributeDefinition) {
+	var indexes []types.GlobalSecondaryIndex
+
+	for _, gsi := range params.GlobalSecondaryIndexes {
+
+		keySchema := []types.KeySchemaElement{
+			{
+				AttributeName: aws.String(gsi.PkAttributeName),
+				KeyType:       types.KeyTypeHash,
+			},
+		}
+		keyAttribute = appendAttributeDefinition(keyAttribute, gsi.PkAttributeName, gsi.PkAttributeType)
+
+		if gsi.SkAttributeName != "" {
+			keySchema = append(keySchema, types.KeySchemaElement{
+				AttributeName: aws.String(gsi.SkAttributeName),
+				KeyType:       types.KeyTypeRange,
+			})
+			keyAttribute = appendAttributeDefinition(keyAttribute, gsi.SkAttributeName, gsi.SkAttributeType)
+		}
+
+		index := types.GlobalSecondaryIndex{
+			IndexName:  aws.String(gsi.IndexName),
+			KeySchema:  keySchema,
+			Projection: getProjection(gsi.ProjectionType, gsi.NonKeyAttributes),
+		}
+
+		// provisioned 테이블은 index 용량도 필요
+		if !params.BillingMode.IsOnDemand {
+			index.ProvisionedThroughput = getProvisionedThroughput(getIndexThroughput(params, gsi.Provisioned))
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return indexes, keyAttribute
+}
+
+func getLocalSecondaryIndexes(params DDBTableParams, keyAttribute []types.AttributeDefinition) ([]types.LocalSecondaryIndex, []types.AttributeDefinition) {
+	var indexes []types.LocalSecondaryIndex
+
+	for _, lsi := range params.LocalSecondaryIndexes {
+
+		keyAttribute = appendAttributeDefinition(keyAttribute, lsi.SkAttributeName, lsi.SkAttributeType)
+
+		indexes = append(indexes, types.LocalSecondaryIndex{
+			IndexName: aws.String(lsi.IndexName),
+			KeySchema: []types.KeySchemaElement{
+				{
+					AttributeName: aws.String(params.keyAttributes().pk),
+					KeyType:       types.KeyTypeHash,
+				},
+				{
+					AttributeName: aws.String(lsi.SkAttributeName),
+					KeyType:       types.KeyTypeRange,
+				},
+			},
+			Projection: getProjection(lsi.ProjectionType, lsi.NonKeyAttributes),
+		})
+	}
+
+	return indexes, keyAttribute
+}
+
+func getProjection(projectionType types.ProjectionType, nonKeyAttributes []string) *types.Projection {
+	if projectionType == "" {
+		projectionType = types.ProjectionTypeAll
+	}
+
+	projection := &types.Projection{
+		ProjectionType: projectionType,
+	}
+
+	if projectionType == types.ProjectionTypeInclude {
+		projection.NonKeyAttributes = nonKeyAttributes
+	}
+
+	return projection
+}
+
+// 같은 attribute 가 table key / index key 로 중복 정의되지 않도록 추가
+func appendAttributeDefinition(keyAttribute []types.AttributeDefinition, name string, attributeType types.ScalarAttributeType) []types.AttributeDefinition {
+	for _, attr := range keyAttribute {
+		if aws.ToString(attr.AttributeName) == name {
+			return keyAttribute
+		}
+	}
+
+	return append(keyAttribute, types.AttributeDefinition{
+		AttributeName: aws.String(name),
+		AttributeType: attributeType,
+	})
+}
+
 func getBillingMode(billingMode DDBBillingMode) types.BillingMode {
 	if billingMode.IsOnDemand {
 		return types.BillingModePayPerRequest
@@ -157,3 +423,19 @@ func getBillingMode(billingMode DDBBillingMode) types.BillingMode {
 
 	return types.BillingModeProvisioned
 }
+
+// index 용량이 0 이면 테이블 용량 사용
+func getIndexThroughput(params DDBTableParams, throughput DDBProvisionedThroughput) DDBProvisionedThroughput {
+	if throughput.ReadCapacityUnits == 0 && throughput.WriteCapacityUnits == 0 {
+		return params.BillingMode.IsProvisioned
+	}
+
+	return throughput
+}
+
+func getProvisionedThroughput(throughput DDBProvisionedThroughput) *types.ProvisionedThroughput {
+	return &types.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(int64(throughput.ReadCapacityUnits)),
+		WriteCapacityUnits: aws.Int64(int64(throughput.WriteCapacityUnits)),
+	}
+}
@@ -2,14 +2,15 @@ package goddb
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
-	"time"
 
-	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/gookit/assert"
+	"github.com/zkfmapf123/gdrm/memdb"
 )
 
 type Message struct {
@@ -19,24 +20,43 @@ type Message struct {
 	Age  int    `dynamodbav:"Age"`
 }
 
+var _ DynamoAPI = (*memdb.DB)(nil)
+
 var (
-	ddbClient *dynamodb.Client
+	ddbClient *memdb.DB
 	client    *DDBClient
 	ctx       context.Context
 )
 
+// in-memory DynamoDB (memdb) 로 시나리오 테스트
 func scenarioBeforeHook() {
 	ctx = context.Background()
 
-	awsClient, _ := config.LoadDefaultConfig(ctx, config.WithRegion("ap-northeast-2"))
-	ddbClient = dynamodb.NewFromConfig(awsClient)
+	ddbClient = memdb.New()
 	client = NewDDB(ddbClient)
 }
 
 func scenarioAfterHook() {
 	client.dropTable("user_logs_1")
 	client.dropTable("user_logs_2")
-	time.Sleep(10 * time.Second)
+}
+
+// PK/SK (S) on-demand 테이블 생성
+func scenarioCreateTables(t *testing.T, tableNames ...string) {
+	for _, tableName := range tableNames {
+		client.AddTable(tableName, DDBTableParams{
+			IsCreate:        true,
+			IsPK:            true,
+			PkAttributeType: types.ScalarAttributeTypeS,
+			IsSK:            true,
+			SkAttributeType: types.ScalarAttributeTypeS,
+			BillingMode: DDBBillingMode{
+				IsOnDemand: true,
+			},
+		})
+	}
+
+	assert.NoError(t, client.Start(ctx, true))
 }
 
 func Test_DDBCreate(t *testing.T) {
@@ -68,12 +88,9 @@ func Test_DDBCreate(t *testing.T) {
 			}).Start(ctx, true)
 
 		assert.NoError(t, err)
-		time.Sleep(10 * time.Second)
 	})
 
-	// DDB 테이블 생성 Wait
-
-	t.Run("2. 테이블 중복 생성 에러 여부", func(t *testing.T) {
+	t.Run("2. 이미 있는 테이블은 다시 생성하지 않음", func(t *testing.T) {
 
 		err := client.
 			AddTable("user_logs_1", DDBTableParams{
@@ -87,7 +104,7 @@ func Test_DDBCreate(t *testing.T) {
 				},
 			}).Start(ctx, true)
 
-		assert.Err(t, err)
+		assert.NoError(t, err)
 
 	})
 
@@ -137,7 +154,6 @@ func Test_DDBCreate(t *testing.T) {
 
 	client.dropTable("user_logs_1")
 	client.dropTable("user_logs_2")
-	time.Sleep(10 * time.Second)
 }
 
 func Test_DDBInfo(t *testing.T) {
@@ -158,7 +174,6 @@ func Test_DDBInfo(t *testing.T) {
 				},
 			}).Start(ctx, true)
 		assert.NoError(t, err)
-		time.Sleep(10 * time.Second)
 	})
 
 	t.Run("1. 테이블 목록 조회 여부", func(t *testing.T) {
@@ -253,7 +268,7 @@ func Test_DDBInfo(t *testing.T) {
 	})
 
 	t.Run("4. Data BatchWrite & Select", func(t *testing.T) {
-		err := client.InsertBatch(ctx,"user_logs_1", []any{
+		err := client.InsertBatch(ctx, "user_logs_1", []any{
 			Message{
 				PK:   "USER#4",
 				SK:   "#PROFILE",
@@ -355,3 +370,313 @@ func Test_DDBInfo(t *testing.T) {
 		assert.Eq(t, age, 20)
 	})
 }
+
+type IndexedUser struct {
+	PK     string `dynamodbav:"PK"`
+	SK     string `dynamodbav:"SK"`
+	Name   string `dynamodbav:"Name"`
+	Email  string `dynamodbav:"Email"`
+	GSI1PK string `dynamodbav:"GSI1PK,omitempty"`
+	GSI1SK string `dynamodbav:"GSI1SK,omitempty"`
+}
+
+func Test_DDBGlobalSecondaryIndex(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	t.Run("1. GSI 포함 테이블 생성", func(t *testing.T) {
+		err := client.
+			AddTable("user_logs_1", DDBTableParams{
+				IsCreate:        true,
+				IsPK:            true,
+				PkAttributeType: types.ScalarAttributeTypeS,
+				IsSK:            true,
+				SkAttributeType: types.ScalarAttributeTypeS,
+				BillingMode: DDBBillingMode{
+					IsProvisioned: DDBProvisionedThroughput{
+						ReadCapacityUnits:  5,
+						WriteCapacityUnits: 5,
+					},
+				},
+				GlobalSecondaryIndexes: []DDBGlobalSecondaryIndex{
+					{
+						IndexName:       "GSI1",
+						PkAttributeName: "GSI1PK",
+						PkAttributeType: types.ScalarAttributeTypeS,
+						SkAttributeName: "GSI1SK",
+						SkAttributeType: types.ScalarAttributeTypeS,
+						ProjectionType:  types.ProjectionTypeKeysOnly,
+					},
+				},
+			}).Start(ctx, true)
+		assert.NoError(t, err)
+
+		output, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Len(t, output.Table.GlobalSecondaryIndexes, 1)
+		assert.Eq(t, *output.Table.GlobalSecondaryIndexes[0].ProvisionedThroughput.ReadCapacityUnits, int64(5))
+		assert.Len(t, output.Table.AttributeDefinitions, 4)
+	})
+
+	t.Run("2. email 로 사용자 조회 (inverted index)", func(t *testing.T) {
+		err := client.InsertBatch(ctx, "user_logs_1", []any{
+			IndexedUser{PK: "USER#1", SK: "#PROFILE", Name: "tom", Email: "tom@email.com", GSI1PK: "tom@email.com", GSI1SK: "USER"},
+			IndexedUser{PK: "USER#2", SK: "#PROFILE", Name: "jerry", Email: "jerry@email.com", GSI1PK: "jerry@email.com", GSI1SK: "USER"},
+			IndexedUser{PK: "USER#1", SK: "ORDER#001", Name: "tom"},
+		})
+		assert.NoError(t, err)
+
+		items, err := client.FindByKeyUseExpression(ctx, "user_logs_1", 10, RangeParams{
+			IndexName:              "GSI1",
+			KeyConditionExpression: "GSI1PK = :email",
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":email": &types.AttributeValueMemberS{Value: "tom@email.com"},
+			},
+		})
+		assert.NoError(t, err)
+
+		results := MarshalMaps[IndexedUser](items)
+		assert.Len(t, results, 1)
+		assert.Eq(t, results[0].PK, "USER#1")
+		assert.Eq(t, results[0].Name, "") // KEYS_ONLY
+	})
+}
+
+type Order struct {
+	PK     string `dynamodbav:"PK"`
+	SK     string `dynamodbav:"SK"`
+	Amount int    `dynamodbav:"Amount"`
+}
+
+func Test_DDBLocalSecondaryIndex(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	t.Run("1. LSI 포함 테이블 생성", func(t *testing.T) {
+		err := client.
+			AddTable("user_logs_1", DDBTableParams{
+				IsCreate:        true,
+				IsPK:            true,
+				PkAttributeType: types.ScalarAttributeTypeS,
+				IsSK:            true,
+				SkAttributeType: types.ScalarAttributeTypeS,
+				BillingMode: DDBBillingMode{
+					IsOnDemand: true,
+				},
+				LocalSecondaryIndexes: []DDBLocalSecondaryIndex{
+					{
+						IndexName:       "LSI1",
+						SkAttributeName: "Amount",
+						SkAttributeType: types.ScalarAttributeTypeN,
+					},
+				},
+			}).Start(ctx, true)
+		assert.NoError(t, err)
+
+		output, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Len(t, output.Table.LocalSecondaryIndexes, 1)
+	})
+
+	t.Run("2. 사용자의 주문을 금액 순서로 조회", func(t *testing.T) {
+		err := client.InsertBatch(ctx, "user_logs_1", []any{
+			Order{PK: "USER#1", SK: "ORDER#001", Amount: 3000},
+			Order{PK: "USER#1", SK: "ORDER#002", Amount: 500},
+			Order{PK: "USER#1", SK: "ORDER#003", Amount: 12000},
+			Order{PK: "USER#2", SK: "ORDER#004", Amount: 100},
+		})
+		assert.NoError(t, err)
+
+		items, err := client.FindByKeyUseExpression(ctx, "user_logs_1", 10, RangeParams{
+			IndexName:              "LSI1",
+			KeyConditionExpression: "PK = :pk AND Amount >= :amount",
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: "USER#1"},
+				":amount": &types.AttributeValueMemberN{Value: "1000"},
+			},
+		})
+		assert.NoError(t, err)
+
+		results := MarshalMaps[Order](items)
+		assert.Len(t, results, 2)
+		assert.Eq(t, results[0].SK, "ORDER#001")
+		assert.Eq(t, results[1].SK, "ORDER#003")
+	})
+}
+
+// userId / createdAt 로 key 가 정의된 기존 테이블
+type Activity struct {
+	UserID    string `dynamodbav:"userId"`
+	CreatedAt string `dynamodbav:"createdAt"`
+	Action    string `dynamodbav:"action"`
+}
+
+type ActivityEntry struct {
+	_      struct{} `gdrm:"pk=USER#{UserID},sk=LOG#{Seq}"`
+	UserID string   `dynamodbav:"-"`
+	Seq    int      `dynamodbav:"-"`
+	Action string   `dynamodbav:"action"`
+}
+
+func Test_DDBKeyAttributeNames(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	client.AddTable("user_logs_1", DDBTableParams{
+		IsCreate:        true,
+		IsPK:            true,
+		PkAttributeName: "userId",
+		PkAttributeType: types.ScalarAttributeTypeS,
+		IsSK:            true,
+		SkAttributeName: "createdAt",
+		SkAttributeType: types.ScalarAttributeTypeS,
+		BillingMode:     DDBBillingMode{IsOnDemand: true},
+	})
+	assert.NoError(t, client.Start(ctx, true))
+
+	t.Run("1. 테이블 key 이름으로 생성", func(t *testing.T) {
+		output, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Eq(t, aws.ToString(output.Table.KeySchema[0].AttributeName), "userId")
+		assert.Eq(t, aws.ToString(output.Table.KeySchema[1].AttributeName), "createdAt")
+	})
+
+	t.Run("2. 저장 / 조회 / 수정 / 삭제", func(t *testing.T) {
+		activity := Activity{UserID: "u1", CreatedAt: "2024-01-01", Action: "login"}
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", activity))
+		assert.True(t, errors.Is(client.Insert(ctx, "user_logs_1", activity), ErrAlreadyExists))
+
+		found, err := Get[Activity](ctx, client, "user_logs_1", "u1", "2024-01-01")
+		assert.NoError(t, err)
+		assert.Eq(t, found, activity)
+
+		_, err = client.Update(ctx, "user_logs_1", "u1", "2024-01-01").Set("action", "logout").Execute()
+		assert.NoError(t, err)
+
+		result, err := client.FindByKeys(ctx, []DDBKey{
+			{TableName: "user_logs_1", PK: "u1", SK: "2024-01-01"},
+			{TableName: "user_logs_1", PK: "u1", SK: "2024-01-02"},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, len(result.Items), 1)
+		assert.Eq(t, len(result.Missing), 1)
+
+		_, err = client.Delete(ctx, "user_logs_1", "u1", "2024-01-01", DeleteParams{})
+		assert.NoError(t, err)
+
+		_, err = client.FindByKey(ctx, "user_logs_1", "u1", "2024-01-01")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("3. Repository / key template", func(t *testing.T) {
+		activities, err := NewRepository[Activity](client, "user_logs_1")
+		assert.NoError(t, err)
+		assert.NoError(t, activities.BatchPut(ctx, []Activity{
+			{UserID: "u2", CreatedAt: "2024-01-01"},
+			{UserID: "u2", CreatedAt: "2024-01-02"},
+		}))
+
+		entries, err := NewRepository[ActivityEntry](client, "user_logs_1")
+		assert.NoError(t, err)
+		assert.NoError(t, entries.Insert(ctx, ActivityEntry{UserID: "u3", Seq: 1, Action: "login"}))
+		assert.NoError(t, entries.Insert(ctx, ActivityEntry{UserID: "u3", Seq: 2, Action: "logout"}))
+
+		// 정수 필드는 0 도 값이라 prefix 가 아니라 SK 까지 일치
+		params, err := entries.KeyPrefix(ActivityEntry{UserID: "u3", Seq: 2})
+		assert.NoError(t, err)
+
+		found, err := entries.Query(ctx, 10, params)
+		assert.NoError(t, err)
+		assert.Eq(t, found, []ActivityEntry{
+			{UserID: "u3", Seq: 2, Action: "logout"},
+		})
+
+		count, err := client.DeletePartition(ctx, "user_logs_1", "u2")
+		assert.NoError(t, err)
+		assert.Eq(t, count, 2)
+	})
+}
+
+// hash key 만 있는 테이블 (session / lock / config)
+type Session struct {
+	PK     string `dynamodbav:"PK"`
+	UserID string `dynamodbav:"UserID"`
+	Hits   int    `dynamodbav:"Hits"`
+}
+
+func Test_DDBHashOnlyTable(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	client.AddTable("user_logs_1", DDBTableParams{
+		IsCreate:        true,
+		IsPK:            true,
+		PkAttributeType: types.ScalarAttributeTypeS,
+		BillingMode:     DDBBillingMode{IsOnDemand: true},
+	})
+	assert.NoError(t, client.Start(ctx, true))
+
+	t.Run("1. 저장 / 조회", func(t *testing.T) {
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Session{PK: "SESSION#1", UserID: "u1"}))
+		assert.True(t, errors.Is(client.Insert(ctx, "user_logs_1", Session{PK: "SESSION#1"}), ErrAlreadyExists))
+
+		item, err := client.FindByKey(ctx, "user_logs_1", "SESSION#1", "")
+		assert.NoError(t, err)
+		assert.Eq(t, item["UserID"], types.AttributeValue(&types.AttributeValueMemberS{Value: "u1"}))
+
+		session, err := GetByTypedKey[Session](ctx, client, "user_logs_1", "SESSION#1", nil)
+		assert.NoError(t, err)
+		assert.Eq(t, session.UserID, "u1")
+	})
+
+	t.Run("2. 수정 / 다건 조회", func(t *testing.T) {
+		_, err := client.Update(ctx, "user_logs_1", "SESSION#1", "").Increment("Hits", 1).Execute()
+		assert.NoError(t, err)
+
+		assert.NoError(t, client.InsertBatch(ctx, "user_logs_1", []any{Session{PK: "SESSION#2"}, Session{PK: "SESSION#3"}}))
+
+		result, err := client.FindByKeys(ctx, []DDBKey{
+			{TableName: "user_logs_1", PK: "SESSION#1"},
+			{TableName: "user_logs_1", PK: "SESSION#2"},
+			{TableName: "user_logs_1", PK: "SESSION#9"},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, len(result.Items), 2)
+		assert.Eq(t, result.Missing, []DDBKey{{TableName: "user_logs_1", PK: "SESSION#9"}})
+
+		_, err = client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "SESSION#1", "a", nil)
+		assert.Err(t, err)
+	})
+
+	t.Run("3. Repository / 삭제", func(t *testing.T) {
+		sessions, err := NewRepository[Session](client, "user_logs_1")
+		assert.NoError(t, err)
+
+		updated, err := sessions.Update(ctx, Session{PK: "SESSION#1"}, func(u *DDBUpdate) {
+			u.Increment("Hits", 1)
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, updated.Hits, 2)
+
+		_, err = sessions.Delete(ctx, Session{PK: "SESSION#1"}, DeleteParams{})
+		assert.NoError(t, err)
+
+		assert.NoError(t, client.DeleteBatch(ctx, []DDBKey{{TableName: "user_logs_1", PK: "SESSION#2"}}))
+
+		count, err := client.DeletePartition(ctx, "user_logs_1", "SESSION#3")
+		assert.NoError(t, err)
+		assert.Eq(t, count, 1)
+
+		result, err := client.FindByKeys(ctx, []DDBKey{
+			{TableName: "user_logs_1", PK: "SESSION#1"},
+			{TableName: "user_logs_1", PK: "SESSION#2"},
+			{TableName: "user_logs_1", PK: "SESSION#3"},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, len(result.Missing), 3)
+	})
+}
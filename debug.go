@@ -5,7 +5,6 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 // 시나리오 테스트 용
@@ -18,13 +17,8 @@ func (c DDBClient) dropTable(tableName string) {
 }
 
 // 시나리오 테스트 용
-func (c DDBClient) truncateRow(tableName, pk string) {
-
-	c.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
-		TableName: aws.String(tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: pk},
-		},
-	})
+func (c DDBClient) truncateRow(tableName, pk, sk string) error {
 
+	_, err := c.Delete(context.Background(), tableName, pk, sk, DeleteParams{})
+	return err
 }
@@ -0,0 +1,213 @@
+package goddb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 삭제 조건
+type DeleteParams struct {
+	Condition       ConditionParams
+	ReturnOldValues bool // 삭제된 item 반환 (ALL_OLD)
+}
+
+// 단건 삭제, ReturnOldValues 면 삭제된 item 반환 (없던 item 이면 nil)
+func (c DDBClient) Delete(ctx context.Context, tableName, pk, sk string, params DeleteParams) (map[string]types.AttributeValue, error) {
+	return c.deleteItem(ctx, "DDBClient.Delete", tableName, pk, sk, params)
+}
+
+func (c DDBClient) deleteItem(ctx context.Context, operation, tableName string, pk, sk any, params DeleteParams) (map[string]types.AttributeValue, error) {
+
+	c.trace(DEBUG, operation, map[string]any{
+		"tableName": tableName,
+		"pk":        pk,
+		"sk":        sk,
+		"condition": params.Condition.ConditionExpression,
+	})
+
+	// N / B key 테이블이면 KeyTypeError
+	key, err := c.typedKey(tableName, pk, sk)
+	if err != nil {
+		c.trace(ERROR, operation+".Key.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(tableName),
+		Key:       key,
+	}
+
+	if params.Condition.ConditionExpression != "" {
+		input.ConditionExpression = aws.String(params.Condition.ConditionExpression)
+		input.ExpressionAttributeNames = params.Condition.ExpressionAttributeNames
+		input.ExpressionAttributeValues = params.Condition.ExpressionAttributeValues
+		input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+
+	if params.ReturnOldValues {
+		input.ReturnValues = types.ReturnValueAllOld
+	}
+
+	output, err := c.client.DeleteItem(ctx, input)
+	if err != nil {
+		c.trace(ERROR, operation+".DeleteItem.Error", map[string]any{
+			"tableName": tableName,
+			"pk":        pk,
+			"sk":        sk,
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	c.trace(INFO, operation+".Success", map[string]any{
+		"tableName": tableName,
+		"pk":        pk,
+		"sk":        sk,
+	})
+
+	return output.Attributes, nil
+}
+
+// 여러 테이블의 key 를 BatchWriteItem 25 개 단위로 나눠서 삭제 (condition 없음)
+func (c DDBClient) DeleteBatch(ctx context.Context, keys []DDBKey) error {
+
+	c.trace(DEBUG, "DDBClient.DeleteBatch", map[string]any{
+		"keyCount": len(keys),
+	})
+
+	batchKeys := make([]batchKey, len(keys))
+	for i, key := range keys {
+		batchKey, err := c.newBatchKey(key.TableName, key.PK, key.SK)
+		if err != nil {
+			c.trace(ERROR, "DDBClient.DeleteBatch.Key.Error", map[string]any{
+				"tableName": key.TableName,
+				"error":     err,
+			})
+			return err
+		}
+		batchKeys[i] = batchKey
+	}
+
+	_, err := c.deleteBatch(ctx, "DDBClient.DeleteBatch", batchKeys)
+	return err
+}
+
+// 중복 key 를 빼고 BatchWriteItem 25 개 단위로 삭제, 삭제 요청한 key 수 반환
+func (c DDBClient) deleteBatch(ctx context.Context, operation string, keys []batchKey) (int, error) {
+
+	// 중복 key 는 BatchWriteItem 에서 에러
+	var unique []batchKey
+	seen := map[string]struct{}{}
+	for _, key := range keys {
+		if _, ok := seen[key.id]; !ok {
+			seen[key.id] = struct{}{}
+			unique = append(unique, key)
+		}
+	}
+
+	for i := 0; i < len(unique); i += BATCH_SIZE {
+		batch := unique[i:min(i+BATCH_SIZE, len(unique))]
+
+		requestItems := map[string][]types.WriteRequest{}
+		for _, key := range batch {
+			requestItems[key.tableName] = append(requestItems[key.tableName], types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{
+					Key: key.key,
+				},
+			})
+		}
+
+		if err := c.batchWrite(ctx, operation, requestItems); err != nil {
+			return 0, err
+		}
+	}
+
+	c.trace(INFO, operation+".Success", map[string]any{
+		"keyCount": len(unique),
+	})
+
+	return len(unique), nil
+}
+
+// PK 파티션 (item collection) 의 모든 item 삭제, 삭제한 item 수 반환
+// pk 는 테이블에 선언된 타입 (S / N / B) 으로 변환
+func (c DDBClient) DeletePartition(ctx context.Context, tableName string, pk any) (int, error) {
+
+	c.trace(DEBUG, "DDBClient.DeletePartition", map[string]any{
+		"tableName": tableName,
+		"pk":        pk,
+	})
+
+	names := c.keyAttributes(tableName)
+
+	pkValue, err := keyValue(tableName, names.pk, c.tables[tableName].PkAttributeType, pk)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.DeletePartition.Key.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return 0, err
+	}
+
+	// hash key 만 있는 테이블은 #sk 없이 조회
+	projection, attributeNames := "#pk", map[string]string{"#pk": names.pk}
+	if names.sk != "" {
+		projection += ", #sk"
+		attributeNames["#sk"] = names.sk
+	}
+
+	var keys []batchKey
+	var startKey map[string]types.AttributeValue
+
+	for {
+		// key 만 가져옴
+		res, err := c.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                aws.String(tableName),
+			KeyConditionExpression:   aws.String("#pk = :pk"),
+			ProjectionExpression:     aws.String(projection),
+			ExpressionAttributeNames: attributeNames,
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk": pkValue,
+			},
+			ExclusiveStartKey: startKey,
+		})
+
+		if err != nil {
+			c.trace(ERROR, "DDBClient.DeletePartition.Query.Error", map[string]any{
+				"tableName": tableName,
+				"pk":        pk,
+				"error":     err,
+			})
+			return 0, err
+		}
+
+		// 조회한 key attribute 를 그대로 DeleteRequest 에 사용
+		for _, item := range res.Items {
+			keys = append(keys, c.itemBatchKey(tableName, item))
+		}
+
+		startKey = res.LastEvaluatedKey
+		if len(startKey) == 0 {
+			break
+		}
+	}
+
+	count, err := c.deleteBatch(ctx, "DDBClient.DeletePartition", keys)
+	if err != nil {
+		return 0, err
+	}
+
+	c.trace(INFO, "DDBClient.DeletePartition.Success", map[string]any{
+		"tableName":    tableName,
+		"pk":           pk,
+		"deletedCount": count,
+	})
+
+	return count, nil
+}
@@ -0,0 +1,85 @@
+package goddb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+func Test_DDBDelete(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1", "user_logs_2")
+
+	t.Run("1. 단건 삭제 (조건 / 삭제된 item 반환)", func(t *testing.T) {
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Name: "leedonggyu", Age: 30}))
+
+		_, err := client.Delete(ctx, "user_logs_1", "USER#1", "#PROFILE", DeleteParams{
+			Condition: ConditionParams{
+				ConditionExpression: "Age > :age",
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":age": &types.AttributeValueMemberN{Value: "40"},
+				},
+			},
+		})
+
+		var condFailed *types.ConditionalCheckFailedException
+		assert.True(t, errors.As(err, &condFailed))
+
+		old, err := client.Delete(ctx, "user_logs_1", "USER#1", "#PROFILE", DeleteParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Message](old).Name, "leedonggyu")
+
+		_, err = client.FindByKey(ctx, "user_logs_1", "USER#1", "#PROFILE")
+		assert.Err(t, err)
+
+		// 없는 item 삭제는 에러 없음
+		old, err = client.Delete(ctx, "user_logs_1", "USER#1", "#PROFILE", DeleteParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Nil(t, old)
+	})
+
+	t.Run("2. 배치 삭제 (여러 테이블 / 25 개 초과)", func(t *testing.T) {
+		scenarioInsertOrders(t, "user_logs_1", 30)
+		assert.NoError(t, client.Insert(ctx, "user_logs_2", Message{PK: "USER#1", SK: "#PROFILE"}))
+
+		var keys []DDBKey
+		for i := 1; i <= 30; i++ {
+			keys = append(keys, DDBKey{TableName: "user_logs_1", PK: "USER#1", SK: fmt.Sprintf("ORDER#%03d", i)})
+		}
+		keys = append(keys, DDBKey{TableName: "user_logs_2", PK: "USER#1", SK: "#PROFILE"}, keys[0])
+
+		assert.NoError(t, client.DeleteBatch(ctx, keys))
+
+		result, err := client.FindByKeys(ctx, keys)
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 0)
+		assert.Len(t, result.Missing, 31)
+	})
+
+	t.Run("3. 파티션 전체 삭제", func(t *testing.T) {
+		scenarioInsertOrders(t, "user_logs_1", 60)
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"}))
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#2", SK: "#PROFILE"}))
+
+		count, err := client.DeletePartition(ctx, "user_logs_1", "USER#1")
+		assert.NoError(t, err)
+		assert.Eq(t, count, 61)
+
+		items, err := client.FindAllByKeyUseExpression(ctx, "user_logs_1", 0, userOrdersParams)
+		assert.NoError(t, err)
+		assert.Len(t, items, 0)
+
+		_, err = client.FindByKey(ctx, "user_logs_1", "USER#2", "#PROFILE")
+		assert.NoError(t, err)
+
+		assert.NoError(t, client.truncateRow("user_logs_1", "USER#2", "#PROFILE"))
+		_, err = client.FindByKey(ctx, "user_logs_1", "USER#2", "#PROFILE")
+		assert.Err(t, err)
+	})
+}
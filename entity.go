@@ -0,0 +1,176 @@
+package goddb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 저장시 entity 타입을 기록하는 attribute
+const EntityTypeAttribute = "EntityType"
+
+type EntityParams struct {
+	Type     string // EntityTypeAttribute 에 저장할 값 (없으면 기록하지 않음)
+	SkPrefix string // EntityType 이 없는 item 은 SK prefix 로 구분 (없으면 key template 의 SK prefix)
+}
+
+type entityType struct {
+	goType   reflect.Type
+	params   EntityParams
+	decodeAs func(index int, item map[string]types.AttributeValue, opts decodeOptions) (any, error)
+}
+
+// item collection 을 등록된 타입으로 나눈 결과 (조회 순서 유지)
+type DDBItemCollection struct {
+	Entities []any                             // 등록된 타입의 값 (T)
+	Unknown  []map[string]types.AttributeValue // 등록된 타입을 찾지 못한 item
+}
+
+// T 를 entity 로 등록 (저장시 EntityType 기록, 조회시 DecodeCollection 으로 구분)
+func RegisterEntity[T any](c *DDBClient, params EntityParams) error {
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("entity type %s must be a struct", t)
+	}
+
+	if params.Type == "" && params.SkPrefix == "" {
+		keys, err := keyTemplatesOf(t)
+		if err != nil {
+			return err
+		}
+		if keys != nil {
+			if kt, ok := keys.template(SortKey); ok {
+				params.SkPrefix = kt.literals[0]
+			}
+		}
+	}
+	if params.Type == "" && params.SkPrefix == "" {
+		return fmt.Errorf("entity type %s needs Type or SkPrefix", t)
+	}
+
+	for other, entity := range c.entities {
+		if other == t {
+			continue
+		}
+		if params.Type != "" && entity.params.Type == params.Type {
+			return fmt.Errorf("entity type %q is already registered by %s", params.Type, other)
+		}
+		if params.Type == "" && entity.params.Type == "" && entity.params.SkPrefix == params.SkPrefix {
+			return fmt.Errorf("entity sk prefix %q is already registered by %s", params.SkPrefix, other)
+		}
+	}
+
+	c.entities[t] = entityType{
+		goType: t,
+		params: params,
+		decodeAs: func(index int, item map[string]types.AttributeValue, opts decodeOptions) (any, error) {
+			return decode[T](index, item, opts)
+		},
+	}
+
+	return nil
+}
+
+// encode + 등록된 entity 면 EntityType 기록
+func (c DDBClient) encodeEntity(tableName string, item any) (map[string]types.AttributeValue, error) {
+	av, err := encode(item, c.keyAttributes(tableName))
+	if err != nil || item == nil {
+		return av, err
+	}
+
+	t := reflect.TypeOf(item)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if entity, ok := c.entities[t]; ok && entity.params.Type != "" {
+		av[EntityTypeAttribute] = &types.AttributeValueMemberS{Value: entity.params.Type}
+	}
+
+	return av, nil
+}
+
+// EntityType 이 있으면 Type 으로, 없으면 가장 긴 SK prefix 로 찾음
+func (c DDBClient) entityOf(item map[string]types.AttributeValue, names keyAttributes) (entityType, bool) {
+	if s, ok := item[EntityTypeAttribute].(*types.AttributeValueMemberS); ok {
+		for _, entity := range c.entities {
+			if entity.params.Type == s.Value {
+				return entity, true
+			}
+		}
+	}
+
+	sk, ok := item[names.sk].(*types.AttributeValueMemberS)
+	if !ok {
+		return entityType{}, false
+	}
+
+	var found entityType
+	for _, entity := range c.entities {
+		prefix := entity.params.SkPrefix
+		if prefix == "" || !strings.HasPrefix(sk.Value, prefix) {
+			continue
+		}
+		if found.goType == nil || len(prefix) > len(found.params.SkPrefix) {
+			found = entity
+		}
+	}
+
+	return found, found.goType != nil
+}
+
+// 여러 타입이 섞인 결과를 등록된 타입으로 변환 (SK prefix 는 SK attribute 기준, 테이블 key 이름이 다르면 QueryCollection 사용)
+func (c DDBClient) DecodeCollection(items []map[string]types.AttributeValue) (DDBItemCollection, error) {
+	return c.decodeCollection(items, c.decodeOptions(""))
+}
+
+func (c DDBClient) decodeCollection(items []map[string]types.AttributeValue, opts decodeOptions) (DDBItemCollection, error) {
+
+	collection := DDBItemCollection{
+		Entities: make([]any, 0, len(items)),
+	}
+
+	for i, item := range items {
+		entity, ok := c.entityOf(item, opts.keys)
+		if !ok {
+			collection.Unknown = append(collection.Unknown, item)
+			continue
+		}
+
+		v, err := entity.decodeAs(i, item, opts)
+		if err != nil {
+			return DDBItemCollection{}, err
+		}
+		collection.Entities = append(collection.Entities, v)
+	}
+
+	return collection, nil
+}
+
+// item collection 한번에 조회 (PK = USER#1 의 profile / order / team 등)
+func (c DDBClient) QueryCollection(ctx context.Context, tableName string, limit int, params RangeParams) (DDBItemCollection, error) {
+
+	items, err := c.FindByKeyUseExpression(ctx, tableName, limit, params)
+	if err != nil {
+		return DDBItemCollection{}, err
+	}
+
+	return c.decodeCollection(items, c.decodeOptions(tableName))
+}
+
+// collection 에서 T 만 골라냄
+func EntitiesOf[T any](collection DDBItemCollection) []T {
+
+	result := []T{}
+	for _, entity := range collection.Entities {
+		if v, ok := entity.(T); ok {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package goddb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+// USER#{UserID} 파티션에 같이 저장되는 entity
+type TeamMember struct {
+	PK   string `dynamodbav:"PK"`
+	SK   string `dynamodbav:"SK"`
+	Role string `dynamodbav:"Role"`
+}
+
+func Test_DDBEntity(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	t.Run("1. 등록", func(t *testing.T) {
+		assert.NoError(t, RegisterEntity[Message](client, EntityParams{Type: "Profile"}))
+		assert.NoError(t, RegisterEntity[TeamMember](client, EntityParams{Type: "TeamMember"}))
+
+		// key template 의 SK prefix 사용
+		assert.NoError(t, RegisterEntity[UserOrder](client, EntityParams{}))
+
+		assert.Err(t, RegisterEntity[Order](client, EntityParams{}))
+		assert.Err(t, RegisterEntity[Order](client, EntityParams{Type: "Profile"}))
+		assert.Err(t, RegisterEntity[Order](client, EntityParams{SkPrefix: "ORDER#"}))
+		assert.Err(t, RegisterEntity[map[string]any](client, EntityParams{Type: "Map"}))
+	})
+
+	t.Run("2. 저장시 EntityType 기록", func(t *testing.T) {
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Name: "leedonggyu"}))
+		assert.NoError(t, client.InsertBatch(ctx, "user_logs_1", []any{
+			&TeamMember{PK: "USER#1", SK: "TEAM#devops", Role: "owner"},
+			UserOrder{UserID: "1", Date: "2024-01-01", OrderID: 1, Amount: 100},
+			UserOrder{UserID: "1", Date: "2024-01-02", OrderID: 2, Amount: 200},
+		}))
+
+		item, err := client.FindByKey(ctx, "user_logs_1", "USER#1", "TEAM#devops")
+		assert.NoError(t, err)
+		assert.Eq(t, item[EntityTypeAttribute], types.AttributeValue(&types.AttributeValueMemberS{Value: "TeamMember"}))
+
+		// Type 이 없으면 기록하지 않음
+		item, err = client.FindByKey(ctx, "user_logs_1", "USER#1", "ORDER#2024-01-01#1")
+		assert.NoError(t, err)
+		_, ok := item[EntityTypeAttribute]
+		assert.False(t, ok)
+
+		// strict 에서도 EntityType 은 unknown 이 아님
+		client.WithStrictDecode(true)
+		defer client.WithStrictDecode(false)
+
+		_, err = Get[TeamMember](ctx, client, "user_logs_1", "USER#1", "TEAM#devops")
+		assert.NoError(t, err)
+	})
+
+	t.Run("3. 섞인 결과를 타입별로 변환", func(t *testing.T) {
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Order{PK: "USER#1", SK: "UNKNOWN#1"}))
+
+		collection, err := client.QueryCollection(ctx, "user_logs_1", 10, RangeParams{
+			KeyConditionExpression: "PK = :pk",
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, len(collection.Entities), 4)
+		assert.Eq(t, len(collection.Unknown), 1)
+
+		profiles := EntitiesOf[Message](collection)
+		assert.Eq(t, len(profiles), 1)
+		assert.Eq(t, profiles[0].Name, "leedonggyu")
+
+		orders := EntitiesOf[UserOrder](collection)
+		assert.Eq(t, len(orders), 2)
+		assert.Eq(t, orders[1].OrderID, 2)
+
+		teams := EntitiesOf[TeamMember](collection)
+		assert.Eq(t, teams, []TeamMember{{PK: "USER#1", SK: "TEAM#devops", Role: "owner"}})
+
+		// tagged union
+		for _, entity := range collection.Entities {
+			switch v := entity.(type) {
+			case Message, UserOrder, TeamMember:
+			default:
+				t.Fatalf("unexpected entity %T", v)
+			}
+		}
+	})
+}
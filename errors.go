@@ -0,0 +1,148 @@
+package goddb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// errors.Is 로 비교할 수 있는 에러 (SDK 에러는 errors.As 로 그대로 꺼낼 수 있음)
+var (
+	ErrNotFound        = errors.New("item not found")
+	ErrAlreadyExists   = errors.New("item already exists")
+	ErrConditionFailed = errors.New("condition check failed")
+	ErrThrottled       = errors.New("request throttled")
+	ErrTableNotFound   = errors.New("table not found")
+	ErrIndexNotFound   = errors.New("index not found")
+	ErrUpdatePending   = errors.New("table update pending")
+)
+
+// sentinel 에러 + 원래 에러
+type ddbError struct {
+	sentinel error
+	err      error
+}
+
+func (e *ddbError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+func (e *ddbError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
+
+// 재시도 후에도 남은 batch 요청 (errors.Is(err, ErrThrottled) 도 true)
+type UnprocessedItemsError struct {
+	Items map[string][]types.WriteRequest    // BatchWriteItem
+	Keys  map[string]types.KeysAndAttributes // BatchGetItem
+}
+
+func (e *UnprocessedItemsError) Error() string {
+	count := 0
+	for _, requests := range e.Items {
+		count += len(requests)
+	}
+	for _, keysAndAttributes := range e.Keys {
+		count += len(keysAndAttributes.Keys)
+	}
+
+	return fmt.Sprintf("unprocessed items: %d", count)
+}
+
+func (e *UnprocessedItemsError) Unwrap() error {
+	return ErrThrottled
+}
+
+// 테이블에 선언된 key 타입과 맞지 않는 key 값
+type KeyTypeError struct {
+	TableName string
+	Attribute string
+	Expected  types.ScalarAttributeType
+	Value     any
+}
+
+func (e *KeyTypeError) Error() string {
+	return fmt.Sprintf("key %s of table %s must be %s, got %T", e.Attribute, e.TableName, e.Expected, e.Value)
+}
+
+// Start 에서 이미 있는 테이블이 DDBTableParams 와 다름
+// Pending 이면 ReconcileUpdate 가 요청했지만 아직 반영되지 않은 차이 (errors.Is(err, ErrUpdatePending) 도 true, 다음 Start 에서 계속)
+type TableSchemaError struct {
+	TableName string
+	Diffs     []SchemaDiff
+	Pending   bool
+}
+
+func (e *TableSchemaError) Error() string {
+	parts := make([]string, len(e.Diffs))
+	for i, diff := range e.Diffs {
+		parts[i] = fmt.Sprintf("%s expected %q, got %q", diff.Field, diff.Expected, diff.Actual)
+	}
+
+	if e.Pending {
+		return fmt.Sprintf("table %s update pending: %s", e.TableName, strings.Join(parts, "; "))
+	}
+
+	return fmt.Sprintf("table %s does not match its params: %s", e.TableName, strings.Join(parts, "; "))
+}
+
+func (e *TableSchemaError) Unwrap() error {
+	if e.Pending {
+		return ErrUpdatePending
+	}
+
+	return nil
+}
+
+// 모든 차이를 ReconcileUpdate 로 적용 가능한지
+func (e *TableSchemaError) Updatable() bool {
+	for _, diff := range e.Diffs {
+		if !diff.Updatable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SDK 에러에 sentinel 에러를 붙임
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var condFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &condFailed) {
+		return &ddbError{sentinel: ErrConditionFailed, err: err}
+	}
+
+	// 기본은 ErrTableNotFound ("Requested resource not found"), message 가 index 를 가리킬때만 ErrIndexNotFound
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		if strings.Contains(notFound.ErrorMessage(), "Index: ") {
+			return &ddbError{sentinel: ErrIndexNotFound, err: err}
+		}
+		return &ddbError{sentinel: ErrTableNotFound, err: err}
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ProvisionedThroughputExceededException",
+			"ThrottlingException",
+			"RequestLimitExceeded":
+			return &ddbError{sentinel: ErrThrottled, err: err}
+
+		// Query / Scan 의 IndexName 이 없는 index
+		case "ValidationException":
+			if strings.Contains(apiErr.ErrorMessage(), "does not have the specified index") {
+				return &ddbError{sentinel: ErrIndexNotFound, err: err}
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+	"github.com/zkfmapf123/gdrm/memdb"
+)
+
+func Test_DDBErrors(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"}))
+
+	t.Run("1. ErrNotFound", func(t *testing.T) {
+		_, err := client.FindByKey(ctx, "user_logs_1", "USER#2", "#PROFILE")
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		_, err = client.Put(ctx, "user_logs_1", Message{PK: "USER#2", SK: "#PROFILE"}, PutParams{Mode: PutReplaceOnly})
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.True(t, errors.Is(err, ErrConditionFailed))
+	})
+
+	t.Run("2. ErrAlreadyExists (ConditionalCheckFailedException 포함)", func(t *testing.T) {
+		err := client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"})
+		assert.True(t, errors.Is(err, ErrAlreadyExists))
+		assert.True(t, errors.Is(err, ErrConditionFailed))
+
+		var condFailed *types.ConditionalCheckFailedException
+		assert.True(t, errors.As(err, &condFailed))
+	})
+
+	t.Run("3. ErrConditionFailed", func(t *testing.T) {
+		_, err := client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Set("Name", "tom").
+			Condition(ConditionParams{ConditionExpression: "attribute_not_exists(PK)"}).
+			Execute()
+		assert.True(t, errors.Is(err, ErrConditionFailed))
+		assert.False(t, errors.Is(err, ErrAlreadyExists))
+	})
+
+	t.Run("4. ErrTableNotFound", func(t *testing.T) {
+		_, err := client.FindByKey(ctx, "unknown_table", "USER#1", "#PROFILE")
+		assert.True(t, errors.Is(err, ErrTableNotFound))
+
+		_, err = client.GetTable("unknown_table")
+		assert.True(t, errors.Is(err, ErrTableNotFound))
+
+		// 테이블은 있고 index 만 없음
+		_, err = client.FindByKeyUseExpression(ctx, "user_logs_1", 10, RangeParams{
+			IndexName:              "unknown_index",
+			KeyConditionExpression: "PK = :pk",
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+			},
+		})
+		assert.True(t, errors.Is(err, ErrIndexNotFound))
+		assert.False(t, errors.Is(err, ErrTableNotFound))
+
+		_, err = client.client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName: aws.String("user_logs_1"),
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+				{Delete: &types.DeleteGlobalSecondaryIndexAction{IndexName: aws.String("unknown_index")}},
+			},
+		})
+		assert.True(t, errors.Is(err, ErrIndexNotFound))
+		assert.False(t, errors.Is(err, ErrTableNotFound))
+
+		// 실제 DynamoDB message
+		err = wrapError(&types.ResourceNotFoundException{Message: aws.String("Requested resource not found")})
+		assert.True(t, errors.Is(err, ErrTableNotFound))
+		assert.False(t, errors.Is(err, ErrIndexNotFound))
+
+		err = wrapError(&types.ResourceNotFoundException{Message: aws.String("Requested resource not found: Table: user_index not found")})
+		assert.True(t, errors.Is(err, ErrTableNotFound))
+	})
+
+	t.Run("5. ErrThrottled / UnprocessedItemsError", func(t *testing.T) {
+		db := &throttledDB{DB: memdb.New(), putThrottles: 10, unprocessedLeft: 10}
+		throttled := NewDDB(db).WithRetryPolicy(fastRetryPolicy)
+
+		throttled.AddTable("user_logs_1", DDBTableParams{
+			IsCreate:        true,
+			IsPK:            true,
+			PkAttributeType: types.ScalarAttributeTypeS,
+			IsSK:            true,
+			SkAttributeType: types.ScalarAttributeTypeS,
+			BillingMode:     DDBBillingMode{IsOnDemand: true},
+		})
+		assert.NoError(t, throttled.Start(context.Background(), true))
+
+		err := throttled.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"})
+		assert.True(t, errors.Is(err, ErrThrottled))
+
+		err = throttled.InsertBatch(ctx, "user_logs_1", []any{Message{PK: "USER#1", SK: "#PROFILE"}})
+		assert.True(t, errors.Is(err, ErrThrottled))
+
+		var unprocessed *UnprocessedItemsError
+		assert.True(t, errors.As(err, &unprocessed))
+		assert.Len(t, unprocessed.Items["user_logs_1"], 1)
+	})
+}
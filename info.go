@@ -45,7 +45,8 @@ func (c DDBClient) GetTable(tableName string) (DDBTableInfoParams, error) {
 
 	if err != nil {
 		c.trace(ERROR, "DDBClient.GetTable.DescribeTable.Error", map[string]any{
-			"error": err,
+			"tableName": tableName,
+			"error":     err,
 		})
 		return DDBTableInfoParams{}, err
 	}
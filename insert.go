@@ -3,9 +3,10 @@ package goddb
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -14,49 +15,118 @@ const (
 	BATCH_SIZE = 25
 )
 
+// Put 조건
+type PutMode int
+
+const (
+	PutUpsert      PutMode = iota // 조건 없이 덮어쓰기
+	PutInsertOnly                 // 없을때만 추가 (attribute_not_exists)
+	PutReplaceOnly                // 있을때만 교체 (attribute_exists)
+)
+
+type PutParams struct {
+	Mode            PutMode
+	Condition       ConditionParams // Mode 조건과 AND 로 결합
+	ReturnOldValues bool            // 덮어쓴 item 반환 (ALL_OLD)
+}
+
+// 없을때만 추가 (이미 있으면 ConditionalCheckFailedException)
 func (c DDBClient) Insert(ctx context.Context, tableName string, item any) error {
-	c.trace(DEBUG, "DDBClient.Insert", map[string]any{
+	_, err := c.Put(ctx, tableName, item, PutParams{
+		Mode: PutInsertOnly,
+	})
+
+	return err
+}
+
+// 단건 저장, ReturnOldValues 면 덮어쓴 item 반환 (새로 추가했으면 nil)
+func (c DDBClient) Put(ctx context.Context, tableName string, item any, params PutParams) (map[string]types.AttributeValue, error) {
+	c.trace(DEBUG, "DDBClient.Put", map[string]any{
 		"tableName": tableName,
 		"item":      item,
+		"mode":      params.Mode,
 	})
 
-	marshalItem, err := attributevalue.MarshalMap(item)
+	marshalItem, err := c.encodeEntity(tableName, item)
 	if err != nil {
-		c.trace(ERROR, "DDBClient.Insert.MarshalMap.Error", map[string]any{
+		c.trace(ERROR, "DDBClient.Put.MarshalMap.Error", map[string]any{
 			"tableName": tableName,
 			"item":      item,
 			"error":     err,
 		})
-		return err
+		return nil, err
 	}
 
-	_, err = c.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(tableName),
-		Item:      marshalItem,
+	input := &dynamodb.PutItemInput{
+		TableName:                 aws.String(tableName),
+		Item:                      marshalItem,
+		ExpressionAttributeNames:  params.Condition.ExpressionAttributeNames,
+		ExpressionAttributeValues: params.Condition.ExpressionAttributeValues,
+	}
 
-		ConditionExpression: aws.String("attribute_not_exists(PK)"),
-	})
+	var conditions []string
+	switch params.Mode {
+	case PutInsertOnly:
+		conditions = append(conditions, "attribute_not_exists(#gdrm_pk)")
+	case PutReplaceOnly:
+		conditions = append(conditions, "attribute_exists(#gdrm_pk)")
+	}
+
+	// 사용자 조건의 placeholder 와 겹치지 않도록 복사해서 테이블 hash key 이름 추가
+	if params.Mode == PutInsertOnly || params.Mode == PutReplaceOnly {
+		names := map[string]string{"#gdrm_pk": c.keyAttributes(tableName).pk}
+		for placeholder, name := range params.Condition.ExpressionAttributeNames {
+			names[placeholder] = name
+		}
+		input.ExpressionAttributeNames = names
+	}
+	if params.Condition.ConditionExpression != "" {
+		conditions = append(conditions, "("+params.Condition.ConditionExpression+")")
+	}
+
+	if len(conditions) > 0 {
+		input.ConditionExpression = aws.String(strings.Join(conditions, " AND "))
+		input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+
+	if params.ReturnOldValues {
+		input.ReturnValues = types.ReturnValueAllOld
+	}
+
+	output, err := c.client.PutItem(ctx, input)
 
 	if err != nil {
 		var condFailed *types.ConditionalCheckFailedException
 		if errors.As(err, &condFailed) {
-			c.trace(ERROR, "DDBClient.Insert.ConditionCheckFailed.Error", map[string]any{
+			// 조건 실패시 기존 item 이 있었는지로 구분
+			switch {
+			case params.Mode == PutInsertOnly && condFailed.Item != nil:
+				err = &ddbError{sentinel: ErrAlreadyExists, err: err}
+			case params.Mode == PutReplaceOnly && condFailed.Item == nil:
+				err = &ddbError{sentinel: ErrNotFound, err: err}
+			}
+
+			c.trace(ERROR, "DDBClient.Put.ConditionCheckFailed.Error", map[string]any{
 				"tableName": tableName,
 				"item":      condFailed.Item,
 				"error":     err,
 			})
-			return err
+			return nil, err
 		}
 
-		return err
+		c.trace(ERROR, "DDBClient.Put.PutItem.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return nil, err
 	}
 
-	c.trace(INFO, "DDBClient.Insert.Success", map[string]any{
+	c.trace(INFO, "DDBClient.Put.Success", map[string]any{
 		"tableName": tableName,
 		"item":      marshalItem,
 	})
 
-	return nil
+	return output.Attributes, nil
 }
 
 // insert batch (conditino 없음)
@@ -79,7 +149,7 @@ func (c DDBClient) InsertBatch(ctx context.Context, tableName string, items []an
 		var writeRequests []types.WriteRequest
 		for _, v := range batch {
 
-			marsharV, err := attributevalue.MarshalMap(v)
+			marsharV, err := c.encodeEntity(tableName, v)
 			if err != nil {
 				c.trace(ERROR, "DDBClient.InsertBatch.MarshalMap.Error", map[string]any{
 					"tableName": tableName,
@@ -97,50 +167,59 @@ func (c DDBClient) InsertBatch(ctx context.Context, tableName string, items []an
 
 		}
 
-		results, err := c.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				tableName: writeRequests,
-			},
-		})
-
-		if err != nil {
-			c.trace(ERROR, "DDBClient.InsertBatch.BatchWriteItem.Error", map[string]any{
-				"tableName": tableName,
-				"error":     err,
-			})
+		if err := c.batchWrite(ctx, "DDBClient.InsertBatch", map[string][]types.WriteRequest{
+			tableName: writeRequests,
+		}); err != nil {
 			return err
 		}
 
-		// retry
-		retryCount := 0
-		for len(results.UnprocessedItems) > 0 && retryCount < 3 {
-			retryCount++
-			results, err = c.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-				RequestItems: results.UnprocessedItems,
-			})
-			if err != nil {
-				c.trace(ERROR, "DDBClient.InsertBatch.BatchWriteItem.Error", map[string]any{
-					"tableName":  tableName,
-					"error":      err,
-					"retryCount": retryCount,
-				})
-				return err
-			}
+		c.trace(INFO, "DDBClient.InsertBatch.Success", map[string]any{
+			"tableName": tableName,
+			"itemCount": len(items),
+		})
+	}
+
+	return nil
+}
+
+// BatchWriteItem + UnprocessedItems 재시도 (backoff)
+func (c DDBClient) batchWrite(ctx context.Context, operation string, requestItems map[string][]types.WriteRequest) error {
+
+	results, err := c.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: requestItems,
+	})
+
+	if err != nil {
+		c.trace(ERROR, operation+".BatchWriteItem.Error", map[string]any{
+			"error": err,
+		})
+		return err
+	}
+
+	started := time.Now()
+	for retryCount := 1; len(results.UnprocessedItems) > 0 && c.client.retry.allow(retryCount, started); retryCount++ {
+
+		if err := c.client.retry.wait(ctx, retryCount); err != nil {
+			return err
 		}
 
-		if len(results.UnprocessedItems) > 0 {
-			c.trace(ERROR, "DDBClient.InsertBatch.BatchWriteItem.UnprocessedItems", map[string]any{
-				"tableName":        tableName,
-				"unprocessedItems": results.UnprocessedItems,
+		results, err = c.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: results.UnprocessedItems,
+		})
+		if err != nil {
+			c.trace(ERROR, operation+".BatchWriteItem.Error", map[string]any{
+				"error":      err,
+				"retryCount": retryCount,
 			})
-			return errors.New("unprocessed items")
+			return err
 		}
+	}
 
-		c.trace(INFO, "DDBClient.InsertBatch.Success", map[string]any{
-			"tableName":      tableName,
-			"itemCount":      len(items),
-			"unprocessItems": len(results.UnprocessedItems),
+	if len(results.UnprocessedItems) > 0 {
+		c.trace(ERROR, operation+".BatchWriteItem.UnprocessedItems", map[string]any{
+			"unprocessedItems": results.UnprocessedItems,
 		})
+		return &UnprocessedItemsError{Items: results.UnprocessedItems}
 	}
 
 	return nil
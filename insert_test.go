@@ -0,0 +1,73 @@
+package goddb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+func Test_DDBPut(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	var condFailed *types.ConditionalCheckFailedException
+
+	t.Run("1. upsert (조건 없이 덮어쓰기)", func(t *testing.T) {
+		old, err := client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Name: "leedonggyu", Age: 30}, PutParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Nil(t, old)
+
+		old, err = client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Name: "dk", Age: 31}, PutParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Message](old).Name, "leedonggyu")
+	})
+
+	t.Run("2. insert only", func(t *testing.T) {
+		_, err := client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"}, PutParams{Mode: PutInsertOnly})
+		assert.True(t, errors.As(err, &condFailed))
+
+		err = client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"})
+		assert.True(t, errors.As(err, &condFailed))
+	})
+
+	t.Run("3. replace only", func(t *testing.T) {
+		_, err := client.Put(ctx, "user_logs_1", Message{PK: "USER#2", SK: "#PROFILE"}, PutParams{Mode: PutReplaceOnly})
+		assert.True(t, errors.As(err, &condFailed))
+
+		_, err = client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Name: "tom", Age: 31}, PutParams{Mode: PutReplaceOnly})
+		assert.NoError(t, err)
+	})
+
+	t.Run("4. 사용자 조건 (Mode 조건과 AND)", func(t *testing.T) {
+		condition := ConditionParams{
+			ConditionExpression: "#age < :age",
+			ExpressionAttributeNames: map[string]string{
+				"#age": "Age",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":age": &types.AttributeValueMemberN{Value: "20"},
+			},
+		}
+
+		_, err := client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Age: 10}, PutParams{
+			Mode:      PutReplaceOnly,
+			Condition: condition,
+		})
+		assert.True(t, errors.As(err, &condFailed))
+		assert.Eq(t, MarshalMap[Message](condFailed.Item).Name, "tom")
+
+		condition.ExpressionAttributeValues[":age"] = &types.AttributeValueMemberN{Value: "40"}
+		old, err := client.Put(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE", Age: 10}, PutParams{
+			Mode:            PutReplaceOnly,
+			Condition:       condition,
+			ReturnOldValues: true,
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Message](old).Age, 31)
+	})
+}
@@ -1,35 +1,104 @@
 package goddb
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// DynamoDB API (gdrm 이 호출하는 SDK operation 목록)
+// *dynamodb.Client 외에도 fake, wrapper, 계측용 client 를 주입할 수 있음
+type DynamoAPI interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
+	UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error)
+}
+
+var _ DynamoAPI = (*dynamodb.Client)(nil)
+
 // DDB
 
 type DDBBillingMode struct {
 	IsOnDemand    bool
-	IsProvisioned struct {
-		ReadCapacityUnits  int
-		WriteCapacityUnits int
-	}
+	IsProvisioned DDBProvisionedThroughput
+}
+
+type DDBProvisionedThroughput struct {
+	ReadCapacityUnits  int
+	WriteCapacityUnits int
+}
+
+// GSI
+type DDBGlobalSecondaryIndex struct {
+	IndexName string
+
+	PkAttributeName string // index hash key
+	PkAttributeType types.ScalarAttributeType
+
+	SkAttributeName string // index range key (없으면 hash key 만 사용)
+	SkAttributeType types.ScalarAttributeType
+
+	ProjectionType   types.ProjectionType // 기본 ALL
+	NonKeyAttributes []string             // ProjectionType = INCLUDE 일때 포함할 attribute
+
+	Provisioned DDBProvisionedThroughput // provisioned 테이블일때 index 용량 (0 이면 테이블 용량 사용)
+}
+
+// LSI (hash key 는 테이블 PK 를 그대로 사용, range key 만 다름)
+type DDBLocalSecondaryIndex struct {
+	IndexName string
+
+	SkAttributeName string // index range key
+	SkAttributeType types.ScalarAttributeType
+
+	ProjectionType   types.ProjectionType // 기본 ALL
+	NonKeyAttributes []string             // ProjectionType = INCLUDE 일때 포함할 attribute
 }
 
 type DDBTableParams struct {
 	IsCreate bool // Table 생성 유무
 
 	IsPK            bool                      // primary key
+	PkAttributeName string                    // hash key 이름 (기본 PK)
 	PkAttributeType types.ScalarAttributeType // Hash
 
-	IsSK            bool // sort key
+	IsSK            bool   // sort key
+	SkAttributeName string // range key 이름 (기본 SK)
 	SkAttributeType types.ScalarAttributeType
 
 	BillingMode DDBBillingMode
+
+	GlobalSecondaryIndexes []DDBGlobalSecondaryIndex
+	LocalSecondaryIndexes  []DDBLocalSecondaryIndex // 테이블 생성시에만 정의 가능
+
+	TTLAttributeName string // 만료 시각 (epoch 초) attribute, 없으면 TTL 사용 안함
 }
 
 type DDBClient struct {
-	client *dynamodb.Client
+	client *throttledClient // retry + rate limit 을 거쳐 DynamoAPI 호출
 	tables map[string]DDBTableParams
+	strict bool // decode 시 struct 에 없는 attribute 가 있으면 에러
+
+	reconcile     ReconcileMode // Start 에서 이미 있는 테이블 처리 방법
+	reconcileWait bool          // ReconcileUpdate 에서 UpdateTable 마다 ACTIVE 까지 대기
+
+	entities map[reflect.Type]entityType // RegisterEntity 로 등록된 타입
 }
 
 // Log
@@ -0,0 +1,75 @@
+package goddb
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Query 결과를 pageSize 단위로 필요할 때마다 가져오면서 순회 (break 하면 더 이상 조회하지 않음)
+func (c DDBClient) FindSeqByKeyUseExpression(ctx context.Context, tableName string, pageSize int, params RangeParams) iter.Seq2[map[string]types.AttributeValue, error] {
+
+	return func(yield func(map[string]types.AttributeValue, error) bool) {
+
+		c.trace(DEBUG, "DDBClient.FindSeqByKeyUseExpression", map[string]any{
+			"tableName":  tableName,
+			"pageSize":   pageSize,
+			"expression": params,
+		})
+
+		var startKey map[string]types.AttributeValue
+
+		for {
+			res, err := c.query(ctx, tableName, pageSize, startKey, params)
+			if err != nil {
+				c.trace(ERROR, "DDBClient.FindSeqByKeyUseExpression.Query.Error", map[string]any{
+					"tableName":  tableName,
+					"expression": params,
+					"error":      err,
+				})
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range res.Items {
+				if err := ctx.Err(); err != nil {
+					yield(nil, err)
+					return
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			startKey = res.LastEvaluatedKey
+			if len(startKey) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// FindSeqByKeyUseExpression 결과를 T 로 변환해서 순회
+func FindSeq[T any](ctx context.Context, c *DDBClient, tableName string, pageSize int, params RangeParams) iter.Seq2[T, error] {
+
+	return func(yield func(T, error) bool) {
+
+		index := 0
+		for item, err := range c.FindSeqByKeyUseExpression(ctx, tableName, pageSize, params) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			// decode 에러는 item 단위로 전달 (계속 순회할지는 호출하는 쪽에서 결정)
+			v, err := decode[T](index, item, c.decodeOptions(tableName))
+			index++
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
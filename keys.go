@@ -0,0 +1,371 @@
+package goddb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// key template 은 struct{} 필드의 gdrm tag 로 선언
+//
+//	type Order struct {
+//		_       struct{} `gdrm:"pk=USER#{UserID},sk=ORDER#{OrderID},GSI1PK=STATUS#{Status}"`
+//		UserID  string   `dynamodbav:"UserID"`
+//		OrderID string   `dynamodbav:"OrderID"`
+//		Status  string   `dynamodbav:"Status"`
+//	}
+//
+// pk / sk 는 테이블의 hash / range key (기본 PK / SK), 나머지는 이름 그대로 attribute 가 됨 (GSI key 등)
+type keyTemplate struct {
+	attribute string
+	template  string
+	literals  []string // placeholder 앞뒤 문자열 (len = len(fields) + 1)
+	names     []string // placeholder 필드 이름
+	fields    [][]int  // placeholder 필드 index
+}
+
+type entityKeys struct {
+	templates []keyTemplate
+}
+
+var entityKeysCache sync.Map // reflect.Type -> *entityKeys
+
+// key template 이 없으면 nil
+func keyTemplatesOf(t reflect.Type) (*entityKeys, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	if cached, ok := entityKeysCache.Load(t); ok {
+		return cached.(*entityKeys), nil
+	}
+
+	var keys *entityKeys
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup("gdrm")
+		if !ok || field.Type != reflect.TypeFor[struct{}]() {
+			continue
+		}
+
+		keys = &entityKeys{}
+		for _, part := range strings.Split(tag, ",") {
+			name, template, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok || name == "" || template == "" {
+				return nil, fmt.Errorf("invalid key template %q in %s", part, t)
+			}
+
+			switch strings.ToLower(name) {
+			case "pk":
+				name = PrimaryKey
+			case "sk":
+				name = SortKey
+			}
+
+			kt, err := parseKeyTemplate(t, name, template)
+			if err != nil {
+				return nil, err
+			}
+			keys.templates = append(keys.templates, kt)
+		}
+		break
+	}
+
+	cached, _ := entityKeysCache.LoadOrStore(t, keys)
+	return cached.(*entityKeys), nil
+}
+
+func parseKeyTemplate(t reflect.Type, attribute, template string) (keyTemplate, error) {
+	kt := keyTemplate{attribute: attribute, template: template}
+
+	rest := template
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			kt.literals = append(kt.literals, rest)
+			break
+		}
+
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return keyTemplate{}, fmt.Errorf("invalid key template %q: missing }", template)
+		}
+
+		name := rest[start+1 : start+end]
+		field, ok := t.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return keyTemplate{}, fmt.Errorf("invalid key template %q: %s has no exported field %s", template, t, name)
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return keyTemplate{}, fmt.Errorf("invalid key template %q: field %s must be a string or integer", template, name)
+		}
+
+		// 두 placeholder 사이에 구분 문자열이 없으면 다시 나눌 수 없음
+		if len(kt.names) > 0 && start == 0 {
+			return keyTemplate{}, fmt.Errorf("invalid key template %q: placeholders must be separated", template)
+		}
+
+		kt.literals = append(kt.literals, rest[:start])
+		kt.names = append(kt.names, name)
+		kt.fields = append(kt.fields, field.Index)
+		rest = rest[start+end+1:]
+	}
+
+	return kt, nil
+}
+
+// 비어있는 placeholder 필드 (string 만, 정수는 0 도 값)
+func emptyKeyField(field reflect.Value) bool {
+	return field.Kind() == reflect.String && field.Len() == 0
+}
+
+// 모든 placeholder 를 채운 key, 비어있는 string 필드가 있으면 에러
+func (kt keyTemplate) render(v reflect.Value) (string, error) {
+	var b strings.Builder
+
+	for i, index := range kt.fields {
+		b.WriteString(kt.literals[i])
+
+		field, err := v.FieldByIndexErr(index)
+		if err != nil || emptyKeyField(field) {
+			return "", fmt.Errorf("key template %s=%s: field %s is empty", kt.attribute, kt.template, kt.names[i])
+		}
+		fmt.Fprint(&b, field.Interface())
+	}
+	b.WriteString(kt.literals[len(kt.literals)-1])
+
+	return b.String(), nil
+}
+
+// 비어있는 첫 string 필드 앞까지의 key (begins_with 용, render 와 같은 기준)
+func (kt keyTemplate) prefix(v reflect.Value) (string, bool) {
+	var b strings.Builder
+
+	for i, index := range kt.fields {
+		b.WriteString(kt.literals[i])
+
+		field, err := v.FieldByIndexErr(index)
+		if err != nil || emptyKeyField(field) {
+			return b.String(), false
+		}
+		fmt.Fprint(&b, field.Interface())
+	}
+	b.WriteString(kt.literals[len(kt.literals)-1])
+
+	return b.String(), true
+}
+
+// key 를 template 으로 나눠서 필드에 채움 (이미 값이 있는 필드는 그대로)
+func (kt keyTemplate) parse(key string, v reflect.Value) bool {
+	if !strings.HasPrefix(key, kt.literals[0]) {
+		return false
+	}
+	rest := key[len(kt.literals[0]):]
+
+	values := make([]string, len(kt.fields))
+	for i := range kt.fields {
+		next := kt.literals[i+1]
+
+		var end int
+		switch {
+		case i < len(kt.fields)-1:
+			end = strings.Index(rest, next)
+		case !strings.HasSuffix(rest, next):
+			end = -1
+		default:
+			end = len(rest) - len(next)
+		}
+		if end < 0 {
+			return false
+		}
+
+		values[i] = rest[:end]
+		rest = rest[end+len(next):]
+	}
+
+	for i, index := range kt.fields {
+		field, err := v.FieldByIndexErr(index)
+		if err != nil || !field.CanSet() || !field.IsZero() {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(values[i])
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n, err := strconv.ParseInt(values[i], 10, 64); err == nil {
+				field.SetInt(n)
+			}
+		default:
+			if n, err := strconv.ParseUint(values[i], 10, 64); err == nil {
+				field.SetUint(n)
+			}
+		}
+	}
+
+	return true
+}
+
+// attributevalue.MarshalMap + key template 으로 PK / SK / GSI key 추가
+func encode(item any, names keyAttributes) (map[string]types.AttributeValue, error) {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := keyTemplatesOf(reflect.TypeOf(item))
+	if err != nil || keys == nil {
+		return av, err
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(item))
+	for _, kt := range keys.templates {
+		// hash key 만 있는 테이블은 sk template 을 쓰지 않음
+		attribute := names.of(kt.attribute)
+		if attribute == "" {
+			continue
+		}
+
+		key, err := kt.render(v)
+		if err != nil {
+			// PK / SK 가 아닌 key 는 비어있으면 생략 (sparse index)
+			if kt.attribute != PrimaryKey && kt.attribute != SortKey {
+				continue
+			}
+			return nil, err
+		}
+
+		av[attribute] = &types.AttributeValueMemberS{Value: key}
+	}
+
+	return av, nil
+}
+
+// key attribute 를 template 으로 나눠서 필드에 채움
+func (keys *entityKeys) fill(v reflect.Value, item map[string]types.AttributeValue, names keyAttributes) {
+	for _, kt := range keys.templates {
+		if s, ok := item[names.of(kt.attribute)].(*types.AttributeValueMemberS); ok {
+			kt.parse(s.Value, v)
+		}
+	}
+}
+
+// template 으로 만든 PK / SK (SK template 이 없으면 "")
+func (keys *entityKeys) key(v reflect.Value) (string, string, error) {
+	var pk, sk string
+
+	for _, kt := range keys.templates {
+		switch kt.attribute {
+		case PrimaryKey, SortKey:
+			key, err := kt.render(v)
+			if err != nil {
+				return "", "", err
+			}
+
+			if kt.attribute == PrimaryKey {
+				pk = key
+			} else {
+				sk = key
+			}
+		}
+	}
+
+	return pk, sk, nil
+}
+
+func (keys *entityKeys) template(attribute string) (keyTemplate, bool) {
+	for _, kt := range keys.templates {
+		if kt.attribute == attribute {
+			return kt, true
+		}
+	}
+
+	return keyTemplate{}, false
+}
+
+// template 의 pk / sk 를 테이블 key 이름으로 바꿈 (나머지는 그대로)
+func (names keyAttributes) of(attribute string) string {
+	switch attribute {
+	case PrimaryKey:
+		return names.pk
+	case SortKey:
+		return names.sk
+	}
+
+	return attribute
+}
+
+// item 의 PK 와 SK prefix 로 조회 조건 생성 (SK 의 string 필드가 비어있으면 그 앞까지 begins_with, 정수 필드는 0 도 값)
+// 테이블 key 이름이 PK / SK 가 아니면 Repository.KeyPrefix 사용
+//
+//	KeyPrefix(Order{UserID: "123"}) // PK = USER#123 AND begins_with(SK, ORDER#)
+func KeyPrefix[T any](item T) (RangeParams, error) {
+	return keyPrefix(item, "", PrimaryKey, SortKey, DDBTableParams{}.keyAttributes())
+}
+
+// GSI / LSI 조회 조건 생성 (pkAttribute / skAttribute 는 template 의 attribute 이름)
+func IndexKeyPrefix[T any](item T, indexName, pkAttribute, skAttribute string) (RangeParams, error) {
+	return keyPrefix(item, indexName, pkAttribute, skAttribute, keyAttributes{pk: pkAttribute, sk: skAttribute})
+}
+
+// pkTemplate / skTemplate 으로 찾은 template 을 names 의 attribute 조건으로 만듦
+func keyPrefix[T any](item T, indexName, pkTemplate, skTemplate string, names keyAttributes) (RangeParams, error) {
+	keys, err := keyTemplatesOf(reflect.TypeFor[T]())
+	if err != nil {
+		return RangeParams{}, err
+	}
+	if keys == nil {
+		return RangeParams{}, fmt.Errorf("%s has no key template", reflect.TypeFor[T]())
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(item))
+
+	pkKey, ok := keys.template(pkTemplate)
+	if !ok {
+		return RangeParams{}, fmt.Errorf("%s has no key template for %s", reflect.TypeFor[T](), pkTemplate)
+	}
+
+	pk, err := pkKey.render(v)
+	if err != nil {
+		return RangeParams{}, err
+	}
+
+	params := RangeParams{
+		IndexName:                indexName,
+		KeyConditionExpression:   "#pk = :pk",
+		ExpressionAttributeNames: map[string]string{"#pk": names.pk},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: pk},
+		},
+	}
+
+	skKey, ok := keys.template(skTemplate)
+	if !ok || skTemplate == "" || names.sk == "" {
+		return params, nil
+	}
+
+	sk, complete := skKey.prefix(v)
+	if complete {
+		params.KeyConditionExpression += " AND #sk = :sk"
+	} else if sk != "" {
+		params.KeyConditionExpression += " AND begins_with(#sk, :sk)"
+	} else {
+		return params, nil
+	}
+	params.ExpressionAttributeNames["#sk"] = names.sk
+	params.ExpressionAttributeValues[":sk"] = &types.AttributeValueMemberS{Value: sk}
+
+	return params, nil
+}
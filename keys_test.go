@@ -0,0 +1,153 @@
+package goddb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+// key template 으로 PK / SK / GSI key 를 만드는 entity
+type UserOrder struct {
+	_       struct{} `gdrm:"pk=USER#{UserID},sk=ORDER#{Date}#{OrderID},GSI1PK=STATUS#{Status}"`
+	UserID  string   `dynamodbav:"-"`
+	Date    string   `dynamodbav:"-"`
+	OrderID int      `dynamodbav:"-"`
+	Status  string   `dynamodbav:"Status,omitempty"`
+	Amount  int      `dynamodbav:"Amount"`
+}
+
+func Test_DDBKeyTemplate(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	t.Run("1. 저장시 PK / SK / GSI key 생성", func(t *testing.T) {
+		_, err := client.Put(ctx, "user_logs_1", UserOrder{UserID: "123", Date: "2024-01-01", OrderID: 1, Status: "PAID", Amount: 100}, PutParams{})
+		assert.NoError(t, err)
+
+		// Status 가 없으면 GSI key 는 생략
+		err = client.InsertBatch(ctx, "user_logs_1", []any{
+			UserOrder{UserID: "123", Date: "2024-01-02", OrderID: 2, Amount: 200},
+			UserOrder{UserID: "456", Date: "2024-01-01", OrderID: 3, Amount: 300},
+		})
+		assert.NoError(t, err)
+
+		item, err := client.FindByKey(ctx, "user_logs_1", "USER#123", "ORDER#2024-01-01#1")
+		assert.NoError(t, err)
+		assert.Eq(t, item["GSI1PK"], types.AttributeValue(&types.AttributeValueMemberS{Value: "STATUS#PAID"}))
+
+		item, err = client.FindByKey(ctx, "user_logs_1", "USER#123", "ORDER#2024-01-02#2")
+		assert.NoError(t, err)
+		_, ok := item["GSI1PK"]
+		assert.False(t, ok)
+
+		// PK / SK 필드가 비어있으면 에러
+		_, err = client.Put(ctx, "user_logs_1", UserOrder{Date: "2024-01-01", OrderID: 4}, PutParams{})
+		assert.Err(t, err)
+	})
+
+	t.Run("2. 조회시 key 를 필드로 복원", func(t *testing.T) {
+		order, err := Get[UserOrder](ctx, client, "user_logs_1", "USER#123", "ORDER#2024-01-01#1")
+		assert.NoError(t, err)
+		assert.Eq(t, order, UserOrder{UserID: "123", Date: "2024-01-01", OrderID: 1, Status: "PAID", Amount: 100})
+
+		// strict 에서도 key attribute 는 unknown 이 아님
+		_, err = UnmarshalMapStrict[UserOrder](map[string]types.AttributeValue{
+			PrimaryKey: &types.AttributeValueMemberS{Value: "USER#1"},
+			SortKey:    &types.AttributeValueMemberS{Value: "ORDER#2024-01-01#1"},
+			"GSI1PK":   &types.AttributeValueMemberS{Value: "STATUS#PAID"},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("3. key prefix 조회 조건", func(t *testing.T) {
+		params, err := KeyPrefix(UserOrder{UserID: "123"})
+		assert.NoError(t, err)
+		assert.Eq(t, params.KeyConditionExpression, "#pk = :pk AND begins_with(#sk, :sk)")
+
+		orders, err := Query[UserOrder](ctx, client, "user_logs_1", 10, params)
+		assert.NoError(t, err)
+		assert.Eq(t, len(orders), 2)
+
+		type datedOrder struct {
+			_      struct{} `gdrm:"pk=USER#{UserID},sk=ORDER#{Date}#{Seq}"`
+			UserID string   `dynamodbav:"-"`
+			Date   string   `dynamodbav:"-"`
+			Seq    string   `dynamodbav:"-"`
+		}
+		params, err = KeyPrefix(datedOrder{UserID: "123", Date: "2024-01-02"})
+		assert.NoError(t, err)
+		assert.Eq(t, params.ExpressionAttributeValues[":sk"], types.AttributeValue(&types.AttributeValueMemberS{Value: "ORDER#2024-01-02#"}))
+
+		orders, err = Query[UserOrder](ctx, client, "user_logs_1", 10, params)
+		assert.NoError(t, err)
+		assert.Eq(t, len(orders), 1)
+		assert.Eq(t, orders[0].OrderID, 2)
+
+		// 정수 필드는 0 도 값 (저장한 key 와 같은 조건)
+		zero := UserOrder{UserID: "123", Date: "2024-01-03", OrderID: 0, Amount: 10}
+		_, err = client.Put(ctx, "user_logs_1", zero, PutParams{})
+		assert.NoError(t, err)
+
+		params, err = KeyPrefix(UserOrder{UserID: "123", Date: "2024-01-03"})
+		assert.NoError(t, err)
+		assert.Eq(t, params.KeyConditionExpression, "#pk = :pk AND #sk = :sk")
+		assert.Eq(t, params.ExpressionAttributeValues[":sk"], types.AttributeValue(&types.AttributeValueMemberS{Value: "ORDER#2024-01-03#0"}))
+
+		orders, err = Query[UserOrder](ctx, client, "user_logs_1", 10, params)
+		assert.NoError(t, err)
+		assert.Eq(t, orders, []UserOrder{zero})
+
+		assert.NoError(t, client.truncateRow("user_logs_1", "USER#123", "ORDER#2024-01-03#0"))
+
+		params, err = KeyPrefix(UserOrder{UserID: "123", Date: "2024-01-01", OrderID: 1})
+		assert.NoError(t, err)
+		assert.Eq(t, params.KeyConditionExpression, "#pk = :pk AND #sk = :sk")
+
+		params, err = IndexKeyPrefix(UserOrder{Status: "PAID"}, "GSI1", "GSI1PK", "")
+		assert.NoError(t, err)
+		assert.Eq(t, params.IndexName, "GSI1")
+		assert.Eq(t, params.KeyConditionExpression, "#pk = :pk")
+
+		// PK 를 만들 수 없으면 에러
+		_, err = KeyPrefix(UserOrder{})
+		assert.Err(t, err)
+
+		_, err = KeyPrefix(Order{PK: "USER#1"})
+		assert.Err(t, err)
+	})
+
+	t.Run("4. 잘못된 template", func(t *testing.T) {
+		type unknownField struct {
+			_ struct{} `gdrm:"pk=USER#{ID}"`
+		}
+		_, err := encode(unknownField{}, DDBTableParams{}.keyAttributes())
+		assert.Err(t, err)
+
+		type unseparated struct {
+			_    struct{} `gdrm:"pk=USER#{A}{B}"`
+			A, B string
+		}
+		_, err = encode(unseparated{A: "a", B: "b"}, DDBTableParams{}.keyAttributes())
+		assert.Err(t, err)
+	})
+
+	t.Run("5. Repository", func(t *testing.T) {
+		orders, err := NewRepository[UserOrder](client, "user_logs_1")
+		assert.NoError(t, err)
+
+		pk, sk, err := orders.Key(UserOrder{UserID: "456", Date: "2024-01-01", OrderID: 3})
+		assert.NoError(t, err)
+		assert.Eq(t, pk, "USER#456")
+		assert.Eq(t, sk, "ORDER#2024-01-01#3")
+
+		updated, err := orders.Update(ctx, UserOrder{UserID: "456", Date: "2024-01-01", OrderID: 3}, func(u *DDBUpdate) {
+			u.Increment("Amount", 50)
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, updated, UserOrder{UserID: "456", Date: "2024-01-01", OrderID: 3, Amount: 350})
+	})
+}
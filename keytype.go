@@ -0,0 +1,300 @@
+package goddb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 테이블에 선언된 PkAttributeType / SkAttributeType 으로 key 값 변환
+// string -> S, 정수 / 실수 -> N, []byte -> B (등록되지 않은 테이블은 값의 타입으로 결정)
+// hash key 만 있는 테이블이면 sk 는 무시
+func (c DDBClient) typedKey(tableName string, pk, sk any) (map[string]types.AttributeValue, error) {
+	params := c.tables[tableName]
+	names := c.keyAttributes(tableName)
+
+	pkValue, err := keyValue(tableName, names.pk, params.PkAttributeType, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	key := map[string]types.AttributeValue{
+		names.pk: pkValue,
+	}
+	if names.sk == "" {
+		return key, nil
+	}
+
+	skValue, err := keyValue(tableName, names.sk, params.SkAttributeType, sk)
+	if err != nil {
+		return nil, err
+	}
+	key[names.sk] = skValue
+
+	return key, nil
+}
+
+func keyValue(tableName, attribute string, expected types.ScalarAttributeType, value any) (types.AttributeValue, error) {
+	v := reflect.ValueOf(value)
+
+	var actual types.ScalarAttributeType
+	var av types.AttributeValue
+
+	switch {
+	case !v.IsValid():
+	case v.Kind() == reflect.String:
+		actual, av = types.ScalarAttributeTypeS, &types.AttributeValueMemberS{Value: v.String()}
+	case v.CanInt():
+		actual, av = types.ScalarAttributeTypeN, &types.AttributeValueMemberN{Value: strconv.FormatInt(v.Int(), 10)}
+	case v.CanUint():
+		actual, av = types.ScalarAttributeTypeN, &types.AttributeValueMemberN{Value: strconv.FormatUint(v.Uint(), 10)}
+	case v.CanFloat():
+		actual, av = types.ScalarAttributeTypeN, &types.AttributeValueMemberN{Value: strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		actual, av = types.ScalarAttributeTypeB, &types.AttributeValueMemberB{Value: v.Bytes()}
+	}
+
+	if av == nil || expected != "" && expected != actual {
+		if expected == "" {
+			expected = "S, N or B"
+		}
+		return nil, &KeyTypeError{TableName: tableName, Attribute: attribute, Expected: expected, Value: value}
+	}
+
+	return av, nil
+}
+
+// 타입이 있는 다건 key (숫자 / binary key 테이블)
+type DDBTypedKey struct {
+	TableName string
+	PK        any
+	SK        any // hash key 만 있는 테이블이면 nil
+}
+
+// batch 요청 key, id 는 테이블 + key attribute 타입 / 값 (중복 제거와 응답 매칭에 사용)
+type batchKey struct {
+	tableName string
+	key       map[string]types.AttributeValue
+	id        string
+}
+
+func (c DDBClient) newBatchKey(tableName string, pk, sk any) (batchKey, error) {
+	key, err := c.typedKey(tableName, pk, sk)
+	if err != nil {
+		return batchKey{}, err
+	}
+
+	return c.itemBatchKey(tableName, key), nil
+}
+
+// item (또는 key) 의 key attribute 로 batchKey 생성
+func (c DDBClient) itemBatchKey(tableName string, item map[string]types.AttributeValue) batchKey {
+	names := c.keyAttributes(tableName)
+
+	key := map[string]types.AttributeValue{
+		names.pk: item[names.pk],
+	}
+	id := strconv.Quote(tableName) + keyID(item[names.pk])
+	if names.sk != "" {
+		key[names.sk] = item[names.sk]
+		id += keyID(item[names.sk])
+	}
+
+	return batchKey{tableName: tableName, key: key, id: id}
+}
+
+// 타입 + quote 된 값 (예: S"USER#1")
+func keyID(av types.AttributeValue) string {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S" + strconv.Quote(v.Value)
+	case *types.AttributeValueMemberN:
+		return "N" + strconv.Quote(v.Value)
+	case *types.AttributeValueMemberB:
+		return "B" + strconv.Quote(string(v.Value))
+	}
+
+	return ""
+}
+
+// 타입이 있는 key 로 다건 조회, 요청 순서대로 반환 (없는 item 은 nil)
+func (c DDBClient) FindByTypedKeys(ctx context.Context, keys []DDBTypedKey) ([]map[string]types.AttributeValue, error) {
+
+	c.trace(DEBUG, "DDBClient.FindByTypedKeys", map[string]any{
+		"keyCount": len(keys),
+	})
+
+	// 중복 key 는 BatchGetItem 에서 에러
+	requested := make([]batchKey, len(keys))
+	var unique []batchKey
+	seen := map[string]struct{}{}
+	for i, key := range keys {
+		batchKey, err := c.newBatchKey(key.TableName, key.PK, key.SK)
+		if err != nil {
+			c.trace(ERROR, "DDBClient.FindByTypedKeys.Key.Error", map[string]any{
+				"tableName": key.TableName,
+				"error":     err,
+			})
+			return nil, err
+		}
+
+		requested[i] = batchKey
+		if _, ok := seen[batchKey.id]; !ok {
+			seen[batchKey.id] = struct{}{}
+			unique = append(unique, batchKey)
+		}
+	}
+
+	items, err := c.batchGet(ctx, "DDBClient.FindByTypedKeys", unique)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]types.AttributeValue, len(keys))
+	for i, key := range requested {
+		result[i] = items[key.id]
+	}
+
+	c.trace(INFO, "DDBClient.FindByTypedKeys.Success", map[string]any{
+		"keyCount":  len(unique),
+		"itemCount": len(items),
+	})
+
+	return result, nil
+}
+
+// 타입이 있는 key 로 단건 삭제 (ReturnOldValues 면 삭제된 item 반환)
+func (c DDBClient) DeleteByTypedKey(ctx context.Context, tableName string, pk, sk any, params DeleteParams) (map[string]types.AttributeValue, error) {
+	return c.deleteItem(ctx, "DDBClient.DeleteByTypedKey", tableName, pk, sk, params)
+}
+
+// 타입이 있는 key 로 다건 삭제 (BatchWriteItem 25 개 단위, condition 없음)
+func (c DDBClient) DeleteBatchByTypedKeys(ctx context.Context, keys []DDBTypedKey) error {
+
+	c.trace(DEBUG, "DDBClient.DeleteBatchByTypedKeys", map[string]any{
+		"keyCount": len(keys),
+	})
+
+	batchKeys := make([]batchKey, len(keys))
+	for i, key := range keys {
+		batchKey, err := c.newBatchKey(key.TableName, key.PK, key.SK)
+		if err != nil {
+			c.trace(ERROR, "DDBClient.DeleteBatchByTypedKeys.Key.Error", map[string]any{
+				"tableName": key.TableName,
+				"error":     err,
+			})
+			return err
+		}
+		batchKeys[i] = batchKey
+	}
+
+	_, err := c.deleteBatch(ctx, "DDBClient.DeleteBatchByTypedKeys", batchKeys)
+	return err
+}
+
+// 타입이 있는 key 로 단건 조회 (숫자 / binary sort key 등, 없으면 ErrNotFound)
+func (c DDBClient) FindByTypedKey(ctx context.Context, tableName string, pk, sk any) (map[string]types.AttributeValue, error) {
+
+	c.trace(DEBUG, "DDBClient.FindByTypedKey", map[string]any{
+		"tableName": tableName,
+		"pk":        pk,
+		"sk":        sk,
+	})
+
+	key, err := c.typedKey(tableName, pk, sk)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.FindByTypedKey.Key.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key:       key,
+	})
+	if err != nil {
+		c.trace(ERROR, "DDBClient.FindByTypedKey.GetItem.Error", map[string]any{
+			"tableName": tableName,
+			"pk":        pk,
+			"sk":        sk,
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	if output.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	return output.Item, nil
+}
+
+// pk 파티션에서 from <= sk <= to 인 item 조회 (from / to 가 nil 이면 그쪽은 제한 없음)
+func (c DDBClient) FindByTypedKeyRange(ctx context.Context, tableName string, limit int, pk, from, to any) ([]map[string]types.AttributeValue, error) {
+
+	params := c.tables[tableName]
+	names := c.keyAttributes(tableName)
+
+	pkValue, err := keyValue(tableName, names.pk, params.PkAttributeType, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	if names.sk == "" && (from != nil || to != nil) {
+		return nil, fmt.Errorf("table %s has no sort key", tableName)
+	}
+
+	rangeParams := RangeParams{
+		KeyConditionExpression:    "#pk = :pk",
+		ExpressionAttributeNames:  map[string]string{"#pk": names.pk},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": pkValue},
+	}
+
+	bounds := map[string]any{}
+	if from != nil {
+		bounds[":from"] = from
+	}
+	if to != nil {
+		bounds[":to"] = to
+	}
+	for placeholder, value := range bounds {
+		av, err := keyValue(tableName, names.sk, params.SkAttributeType, value)
+		if err != nil {
+			return nil, err
+		}
+		rangeParams.ExpressionAttributeValues[placeholder] = av
+	}
+
+	switch {
+	case from != nil && to != nil:
+		rangeParams.KeyConditionExpression += " AND #sk BETWEEN :from AND :to"
+	case from != nil:
+		rangeParams.KeyConditionExpression += " AND #sk >= :from"
+	case to != nil:
+		rangeParams.KeyConditionExpression += " AND #sk <= :to"
+	}
+	if from != nil || to != nil {
+		rangeParams.ExpressionAttributeNames["#sk"] = names.sk
+	}
+
+	return c.FindByKeyUseExpression(ctx, tableName, limit, rangeParams)
+}
+
+// FindByTypedKey 결과를 T 로 변환
+func GetByTypedKey[T any](ctx context.Context, c *DDBClient, tableName string, pk, sk any) (T, error) {
+
+	item, err := c.FindByTypedKey(ctx, tableName, pk, sk)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decode[T](0, item, c.decodeOptions(tableName))
+}
@@ -0,0 +1,221 @@
+package goddb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+// sort key 가 숫자 (timestamp) 인 테이블
+type Metric struct {
+	PK    string  `dynamodbav:"PK"`
+	SK    int64   `dynamodbav:"SK"`
+	Value float64 `dynamodbav:"Value"`
+}
+
+func Test_DDBTypedKey(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	client.AddTable("user_logs_1", DDBTableParams{
+		IsCreate:        true,
+		IsPK:            true,
+		PkAttributeType: types.ScalarAttributeTypeS,
+		IsSK:            true,
+		SkAttributeType: types.ScalarAttributeTypeN,
+		BillingMode:     DDBBillingMode{IsOnDemand: true},
+	})
+	assert.NoError(t, client.Start(ctx, true))
+
+	for i := int64(1); i <= 5; i++ {
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Metric{PK: "CPU", SK: 1700000000 + i, Value: float64(i)}))
+	}
+
+	t.Run("1. 숫자 sort key 단건 조회", func(t *testing.T) {
+		item, err := client.FindByTypedKey(ctx, "user_logs_1", "CPU", 1700000003)
+		assert.NoError(t, err)
+		assert.Eq(t, item["Value"], types.AttributeValue(&types.AttributeValueMemberN{Value: "3"}))
+
+		metric, err := GetByTypedKey[Metric](ctx, client, "user_logs_1", "CPU", uint32(1700000005))
+		assert.NoError(t, err)
+		assert.Eq(t, metric.Value, 5.0)
+
+		_, err = client.FindByTypedKey(ctx, "user_logs_1", "CPU", 1)
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("2. 숫자 sort key 범위 조회", func(t *testing.T) {
+		items, err := client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", 1700000002, 1700000004)
+		assert.NoError(t, err)
+		assert.Eq(t, len(items), 3)
+
+		items, err = client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", 1700000004, nil)
+		assert.NoError(t, err)
+		assert.Eq(t, len(items), 2)
+
+		items, err = client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", nil, nil)
+		assert.NoError(t, err)
+		assert.Eq(t, len(items), 5)
+	})
+
+	t.Run("3. 타입 불일치", func(t *testing.T) {
+		var keyErr *KeyTypeError
+
+		_, err := client.FindByTypedKey(ctx, "user_logs_1", "CPU", "1700000001")
+		assert.True(t, errors.As(err, &keyErr))
+		assert.Eq(t, keyErr.Attribute, SortKey)
+		assert.Eq(t, keyErr.Expected, types.ScalarAttributeTypeN)
+
+		_, err = client.FindByTypedKey(ctx, "user_logs_1", 1, 1700000001)
+		assert.True(t, errors.As(err, &keyErr))
+		assert.Eq(t, keyErr.Attribute, PrimaryKey)
+
+		// string key 조회도 선언된 타입으로 검사
+		_, err = client.FindByKey(ctx, "user_logs_1", "CPU", "1700000001")
+		assert.True(t, errors.As(err, &keyErr))
+
+		_, err = client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", "a", nil)
+		assert.True(t, errors.As(err, &keyErr))
+
+		_, err = client.FindByTypedKey(ctx, "unknown_table", "CPU", struct{}{})
+		assert.True(t, errors.As(err, &keyErr))
+	})
+	t.Run("4. string key 쓰기 / batch / transaction 도 타입 검사", func(t *testing.T) {
+		var keyErr *KeyTypeError
+
+		_, err := client.Update(ctx, "user_logs_1", "CPU", "1700000001").Set("Value", 10).Execute()
+		assert.True(t, errors.As(err, &keyErr))
+		assert.Eq(t, keyErr.Attribute, SortKey)
+
+		_, err = client.Delete(ctx, "user_logs_1", "CPU", "1700000001", DeleteParams{})
+		assert.True(t, errors.As(err, &keyErr))
+
+		_, err = client.FindByKeys(ctx, []DDBKey{{TableName: "user_logs_1", PK: "CPU", SK: "1700000001"}})
+		assert.True(t, errors.As(err, &keyErr))
+
+		err = client.DeleteBatch(ctx, []DDBKey{{TableName: "user_logs_1", PK: "CPU", SK: "1700000001"}})
+		assert.True(t, errors.As(err, &keyErr))
+
+		err = client.Transaction().
+			Update("user_logs_1", "CPU", "1700000001", UpdateParams{UpdateExpression: "SET #v = :v"}).
+			Delete("user_logs_1", "CPU", "1700000002", ConditionParams{}).
+			Execute(ctx)
+		assert.True(t, errors.As(err, &keyErr))
+
+		_, err = client.TransactGet().Get("user_logs_1", "CPU", "1700000001").Execute(ctx)
+		assert.True(t, errors.As(err, &keyErr))
+
+		// 아무것도 바뀌지 않음
+		metric, err := GetByTypedKey[Metric](ctx, client, "user_logs_1", "CPU", 1700000001)
+		assert.NoError(t, err)
+		assert.Eq(t, metric.Value, 1.0)
+	})
+
+	t.Run("5. 타입이 있는 key 로 쓰기 / batch / transaction", func(t *testing.T) {
+		value := func(item map[string]types.AttributeValue) types.AttributeValue {
+			return item["Value"]
+		}
+		number := func(n string) types.AttributeValue {
+			return &types.AttributeValueMemberN{Value: n}
+		}
+
+		_, err := client.UpdateByTypedKey(ctx, "user_logs_1", "CPU", 1700000001).Set("Value", 10).Execute()
+		assert.NoError(t, err)
+
+		items, err := client.FindByTypedKeys(ctx, []DDBTypedKey{
+			{TableName: "user_logs_1", PK: "CPU", SK: 1700000001},
+			{TableName: "user_logs_1", PK: "CPU", SK: int64(1700000002)},
+			{TableName: "user_logs_1", PK: "CPU", SK: 1},
+			{TableName: "user_logs_1", PK: "CPU", SK: 1700000001},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, items, 4)
+		assert.Eq(t, value(items[0]), number("10"))
+		assert.Eq(t, value(items[1]), number("2"))
+		assert.Nil(t, items[2])
+		assert.Eq(t, items[3], items[0])
+
+		err = client.Transaction().
+			UpdateByTypedKey("user_logs_1", "CPU", 1700000002, UpdateParams{
+				UpdateExpression:          "SET #v = :v",
+				ExpressionAttributeNames:  map[string]string{"#v": "Value"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":v": number("20")},
+			}).
+			ConditionCheckByTypedKey("user_logs_1", "CPU", 1700000003, ConditionParams{ConditionExpression: "attribute_exists(PK)"}).
+			DeleteByTypedKey("user_logs_1", "CPU", 1700000004, ConditionParams{}).
+			Execute(ctx)
+		assert.NoError(t, err)
+
+		got, err := client.TransactGet().
+			GetByTypedKey("user_logs_1", "CPU", 1700000002).
+			GetByTypedKey("user_logs_1", "CPU", 1700000004).
+			Execute(ctx)
+		assert.NoError(t, err)
+		assert.Eq(t, value(got[0]), number("20"))
+		assert.Nil(t, got[1])
+
+		old, err := client.DeleteByTypedKey(ctx, "user_logs_1", "CPU", 1700000001, DeleteParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, value(old), number("10"))
+
+		assert.NoError(t, client.DeleteBatchByTypedKeys(ctx, []DDBTypedKey{
+			{TableName: "user_logs_1", PK: "CPU", SK: 1700000002},
+			{TableName: "user_logs_1", PK: "CPU", SK: 1700000003},
+			{TableName: "user_logs_1", PK: "CPU", SK: 1700000003},
+		}))
+
+		remaining, err := client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, remaining, 1)
+		assert.Eq(t, remaining[0]["SK"], number("1700000005"))
+	})
+
+	t.Run("6. 숫자 key 파티션 삭제", func(t *testing.T) {
+		for i := int64(1); i <= 3; i++ {
+			assert.NoError(t, client.Insert(ctx, "user_logs_1", Metric{PK: "MEM", SK: i, Value: float64(i)}))
+		}
+
+		count, err := client.DeletePartition(ctx, "user_logs_1", "MEM")
+		assert.NoError(t, err)
+		assert.Eq(t, count, 3)
+
+		items, err := client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "MEM", nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, items, 0)
+
+		// 다른 파티션은 그대로
+		items, err = client.FindByTypedKeyRange(ctx, "user_logs_1", 10, "CPU", nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		// 숫자 partition key
+		client.AddTable("user_logs_2", DDBTableParams{
+			IsCreate:        true,
+			IsPK:            true,
+			PkAttributeType: types.ScalarAttributeTypeN,
+			IsSK:            true,
+			SkAttributeType: types.ScalarAttributeTypeN,
+			BillingMode:     DDBBillingMode{IsOnDemand: true},
+		})
+		assert.NoError(t, client.Start(ctx, true))
+
+		type counter struct {
+			PK int `dynamodbav:"PK"`
+			SK int `dynamodbav:"SK"`
+		}
+		for i := 1; i <= 2; i++ {
+			assert.NoError(t, client.Insert(ctx, "user_logs_2", counter{PK: 7, SK: i}))
+		}
+
+		count, err = client.DeletePartition(ctx, "user_logs_2", 7)
+		assert.NoError(t, err)
+		assert.Eq(t, count, 2)
+
+		_, err = client.DeletePartition(ctx, "user_logs_2", "7")
+		var keyErr *KeyTypeError
+		assert.True(t, errors.As(err, &keyErr))
+	})
+}
@@ -0,0 +1,378 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// 재시도 정책 (exponential backoff + full jitter)
+// DDBClient 를 거치는 요청은 SDK 의 재시도 (aws.Retryer) 를 끄고 이 정책으로만 재시도
+type DDBRetryPolicy struct {
+	MaxAttempts    int           // 최초 시도 포함 최대 시도 횟수 (1 이면 재시도 없음)
+	BaseDelay      time.Duration // 첫 재시도 대기 시간 상한
+	MaxDelay       time.Duration // 재시도 대기 시간 상한
+	MaxElapsedTime time.Duration // 첫 시도부터 전체 허용 시간 (0 이면 제한 없음)
+}
+
+var DefaultRetryPolicy = DDBRetryPolicy{
+	MaxAttempts:    5,
+	BaseDelay:      50 * time.Millisecond,
+	MaxDelay:       5 * time.Second,
+	MaxElapsedTime: 30 * time.Second,
+}
+
+// 초당 capacity unit 제한 (0 이면 제한 없음)
+type DDBRateLimit struct {
+	ReadCapacityUnits  int
+	WriteCapacityUnits int
+}
+
+func (c *DDBClient) WithRetryPolicy(policy DDBRetryPolicy) *DDBClient {
+	c.client.retry = policy
+	return c
+}
+
+func (c *DDBClient) WithRateLimit(limit DDBRateLimit) *DDBClient {
+	c.client.read = newTokenBucket(limit.ReadCapacityUnits)
+	c.client.write = newTokenBucket(limit.WriteCapacityUnits)
+	return c
+}
+
+// attempt 번째 재시도 전 대기 시간
+func (p DDBRetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
+
+// attempt 번 시도한 뒤 더 시도할 수 있는지
+func (p DDBRetryPolicy) allow(attempt int, started time.Time) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if p.MaxElapsedTime > 0 && time.Since(started) >= p.MaxElapsedTime {
+		return false
+	}
+
+	return true
+}
+
+func (p DDBRetryPolicy) wait(ctx context.Context, attempt int) error {
+	return sleep(ctx, p.backoff(attempt))
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttling / 일시적인 서버 에러만 재시도
+func isRetryable(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.ErrorCode() {
+	case "ProvisionedThroughputExceededException",
+		"ThrottlingException",
+		"RequestLimitExceeded",
+		"InternalServerError",
+		"ServiceUnavailable":
+		return true
+	}
+
+	return false
+}
+
+// token bucket (초당 rate 만큼 채워지고 최대 1초치 만큼 쌓임)
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &tokenBucket{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// n 만큼 가져가고 부족하면 채워질 때까지 대기
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	if b == nil || n <= 0 {
+		return nil
+	}
+
+	return sleep(ctx, b.take(n))
+}
+
+// 대기 없이 n 만큼 가져감 (모자라면 다음 요청이 대기)
+func (b *tokenBucket) take(n float64) time.Duration {
+	if b == nil || n <= 0 {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.rate, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= n
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// 모든 DynamoAPI 호출이 거쳐가는 client (rate limit + retry)
+type throttledClient struct {
+	api   DynamoAPI
+	retry DDBRetryPolicy
+	read  *tokenBucket
+	write *tokenBucket
+}
+
+var _ DynamoAPI = (*throttledClient)(nil)
+
+func newThrottledClient(api DynamoAPI) *throttledClient {
+	return &throttledClient{
+		api:   api,
+		retry: DefaultRetryPolicy,
+	}
+}
+
+// SDK retryer 는 끄고 DDBRetryPolicy 로만 재시도 (SDK 재시도가 겹치면 시도 횟수가 곱해짐)
+func (t *throttledClient) options(optFns []func(*dynamodb.Options)) []func(*dynamodb.Options) {
+	return append(optFns[:len(optFns):len(optFns)], func(o *dynamodb.Options) {
+		o.Retryer = aws.NopRetryer{}
+	})
+}
+
+// 요청 전에 차감할 capacity unit 추정치 (item 당 1 unit)
+type capacity struct {
+	read  float64
+	write float64
+}
+
+func call[O any](ctx context.Context, t *throttledClient, units capacity, fn func() (O, error)) (O, error) {
+	started := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		var zero O
+
+		if err := t.read.wait(ctx, units.read); err != nil {
+			return zero, err
+		}
+		if err := t.write.wait(ctx, units.write); err != nil {
+			return zero, err
+		}
+
+		output, err := fn()
+		if err == nil || !isRetryable(err) || !t.retry.allow(attempt, started) {
+			return output, wrapError(err)
+		}
+
+		if err := t.retry.wait(ctx, attempt); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// 실제 소비량이 추정치보다 크면 차이만큼 추가로 차감
+func (t *throttledClient) consumed(bucket *tokenBucket, estimated float64, consumed ...types.ConsumedCapacity) {
+	var total float64
+	for _, c := range consumed {
+		if c.CapacityUnits != nil {
+			total += *c.CapacityUnits
+		}
+	}
+
+	bucket.take(total - estimated)
+}
+
+func (t *throttledClient) consumedCapacity(bucket *tokenBucket) types.ReturnConsumedCapacity {
+	if bucket == nil {
+		return ""
+	}
+
+	return types.ReturnConsumedCapacityTotal
+}
+
+func (t *throttledClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return call(ctx, t, capacity{write: 1}, func() (*dynamodb.PutItemOutput, error) {
+		return t.api.PutItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return call(ctx, t, capacity{read: 1}, func() (*dynamodb.GetItemOutput, error) {
+		return t.api.GetItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	// 호출한 쪽의 input 은 바꾸지 않음
+	input := *params
+	if input.ReturnConsumedCapacity == "" {
+		input.ReturnConsumedCapacity = t.consumedCapacity(t.read)
+	}
+
+	output, err := call(ctx, t, capacity{read: 1}, func() (*dynamodb.QueryOutput, error) {
+		return t.api.Query(ctx, &input, t.options(optFns)...)
+	})
+
+	if err == nil && output.ConsumedCapacity != nil {
+		t.consumed(t.read, 1, *output.ConsumedCapacity)
+	}
+
+	return output, err
+}
+
+func (t *throttledClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	// 호출한 쪽의 input 은 바꾸지 않음
+	input := *params
+	if input.ReturnConsumedCapacity == "" {
+		input.ReturnConsumedCapacity = t.consumedCapacity(t.read)
+	}
+
+	output, err := call(ctx, t, capacity{read: 1}, func() (*dynamodb.ScanOutput, error) {
+		return t.api.Scan(ctx, &input, t.options(optFns)...)
+	})
+
+	if err == nil && output.ConsumedCapacity != nil {
+		t.consumed(t.read, 1, *output.ConsumedCapacity)
+	}
+
+	return output, err
+}
+
+func (t *throttledClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	units := 0
+	for _, keysAndAttributes := range params.RequestItems {
+		units += len(keysAndAttributes.Keys)
+	}
+
+	return call(ctx, t, capacity{read: float64(units)}, func() (*dynamodb.BatchGetItemOutput, error) {
+		return t.api.BatchGetItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	units := 0
+	for _, requests := range params.RequestItems {
+		units += len(requests)
+	}
+
+	return call(ctx, t, capacity{write: float64(units)}, func() (*dynamodb.BatchWriteItemOutput, error) {
+		return t.api.BatchWriteItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.CreateTableOutput, error) {
+		return t.api.CreateTable(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.DescribeTableOutput, error) {
+		return t.api.DescribeTable(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.ListTablesOutput, error) {
+		return t.api.ListTables(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.DeleteTableOutput, error) {
+		return t.api.DeleteTable(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.UpdateTableOutput, error) {
+		return t.api.UpdateTable(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.UpdateTimeToLiveOutput, error) {
+		return t.api.UpdateTimeToLive(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	return call(ctx, t, capacity{}, func() (*dynamodb.DescribeTimeToLiveOutput, error) {
+		return t.api.DescribeTimeToLive(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return call(ctx, t, capacity{write: 1}, func() (*dynamodb.UpdateItemOutput, error) {
+		return t.api.UpdateItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return call(ctx, t, capacity{write: 1}, func() (*dynamodb.DeleteItemOutput, error) {
+		return t.api.DeleteItem(ctx, params, t.options(optFns)...)
+	})
+}
+
+// 트랜잭션은 item 당 2 unit
+func (t *throttledClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return call(ctx, t, capacity{write: float64(2 * len(params.TransactItems))}, func() (*dynamodb.TransactWriteItemsOutput, error) {
+		return t.api.TransactWriteItems(ctx, params, t.options(optFns)...)
+	})
+}
+
+func (t *throttledClient) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+	return call(ctx, t, capacity{read: float64(2 * len(params.TransactItems))}, func() (*dynamodb.TransactGetItemsOutput, error) {
+		return t.api.TransactGetItems(ctx, params, t.options(optFns)...)
+	})
+}
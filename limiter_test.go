@@ -0,0 +1,184 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+	"github.com/zkfmapf123/gdrm/memdb"
+)
+
+// throttling 을 흉내내는 DynamoAPI
+type throttledDB struct {
+	*memdb.DB
+	putCalls        int
+	putThrottles    int
+	unprocessedLeft int
+	unprocessedKeys int
+	putOptions      []func(*dynamodb.Options)
+}
+
+func (db *throttledDB) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	db.putCalls++
+	db.putOptions = optFns
+	if db.putThrottles > 0 {
+		db.putThrottles--
+		return nil, &types.ProvisionedThroughputExceededException{Message: aws.String("throttled")}
+	}
+
+	return db.DB.PutItem(ctx, params, optFns...)
+}
+
+func (db *throttledDB) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	if db.unprocessedLeft > 0 {
+		db.unprocessedLeft--
+		return &dynamodb.BatchWriteItemOutput{UnprocessedItems: params.RequestItems}, nil
+	}
+
+	return db.DB.BatchWriteItem(ctx, params, optFns...)
+}
+
+func (db *throttledDB) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	if db.unprocessedKeys > 0 {
+		db.unprocessedKeys--
+		return &dynamodb.BatchGetItemOutput{UnprocessedKeys: params.RequestItems}, nil
+	}
+
+	return db.DB.BatchGetItem(ctx, params, optFns...)
+}
+
+var fastRetryPolicy = DDBRetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    5 * time.Millisecond,
+}
+
+func Test_DDBRetry(t *testing.T) {
+
+	ctx = context.Background()
+	db := &throttledDB{DB: memdb.New()}
+	client = NewDDB(db).WithRetryPolicy(fastRetryPolicy)
+	scenarioCreateTables(t, "user_logs_1")
+
+	t.Run("1. throttling 후 재시도 성공", func(t *testing.T) {
+		db.putCalls, db.putThrottles = 0, 2
+
+		err := client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"})
+		assert.NoError(t, err)
+		assert.Eq(t, db.putCalls, 3)
+	})
+
+	t.Run("2. 최대 시도 횟수 초과", func(t *testing.T) {
+		db.putCalls, db.putThrottles = 0, 10
+
+		err := client.Insert(ctx, "user_logs_1", Message{PK: "USER#2", SK: "#PROFILE"})
+
+		var throttled *types.ProvisionedThroughputExceededException
+		assert.True(t, errors.As(err, &throttled))
+		assert.Eq(t, db.putCalls, fastRetryPolicy.MaxAttempts)
+	})
+
+	t.Run("3. 조건 실패는 재시도 하지 않음", func(t *testing.T) {
+		db.putCalls, db.putThrottles = 0, 0
+
+		err := client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "#PROFILE"})
+		assert.Err(t, err)
+		assert.Eq(t, db.putCalls, 1)
+	})
+
+	t.Run("4. InsertBatch UnprocessedItems backoff 재시도", func(t *testing.T) {
+		db.unprocessedLeft = 2
+
+		err := client.InsertBatch(ctx, "user_logs_1", []any{
+			Message{PK: "USER#10", SK: "#PROFILE"},
+			Message{PK: "USER#11", SK: "#PROFILE"},
+		})
+		assert.NoError(t, err)
+
+		db.unprocessedLeft = 10
+
+		err = client.InsertBatch(ctx, "user_logs_1", []any{
+			Message{PK: "USER#12", SK: "#PROFILE"},
+		})
+		assert.Err(t, err)
+	})
+
+	t.Run("5. context 취소시 대기 중단", func(t *testing.T) {
+		client.WithRetryPolicy(DDBRetryPolicy{MaxAttempts: 10, BaseDelay: time.Hour, MaxDelay: time.Hour})
+		defer client.WithRetryPolicy(fastRetryPolicy)
+
+		db.putThrottles = 10
+		cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		err := client.Insert(cancelCtx, "user_logs_1", Message{PK: "USER#3", SK: "#PROFILE"})
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("6. SDK 재시도는 끄고 DDBRetryPolicy 로만 재시도", func(t *testing.T) {
+		db.putCalls, db.putThrottles = 0, 0
+
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#20", SK: "#PROFILE"}))
+
+		options := dynamodb.Options{Retryer: retry.NewStandard()}
+		for _, fn := range db.putOptions {
+			fn(&options)
+		}
+		assert.Eq(t, options.Retryer, aws.Retryer(aws.NopRetryer{}))
+	})
+}
+
+func Test_DDBBackoff(t *testing.T) {
+
+	policy := DDBRetryPolicy{MaxAttempts: 20, BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+	for attempt := 1; attempt < 20; attempt++ {
+		delay := policy.backoff(attempt)
+		assert.True(t, delay >= 0 && delay <= policy.MaxDelay)
+	}
+
+	assert.False(t, policy.allow(20, time.Now()))
+	assert.True(t, policy.allow(1, time.Now()))
+
+	policy.MaxElapsedTime = time.Second
+	assert.False(t, policy.allow(1, time.Now().Add(-2*time.Second)))
+}
+
+func Test_DDBRateLimit(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	client.WithRateLimit(DDBRateLimit{WriteCapacityUnits: 100})
+
+	// burst 100 + 50 개는 0.5 초 대기
+	started := time.Now()
+	for i := 0; i < 150; i++ {
+		err := client.Insert(ctx, "user_logs_1", Message{PK: fmt.Sprintf("USER#%d", i), SK: "#PROFILE"})
+		assert.NoError(t, err)
+	}
+
+	assert.True(t, time.Since(started) >= 400*time.Millisecond)
+
+	// read 제한이 있어도 호출한 쪽의 input 은 그대로
+	client.WithRateLimit(DDBRateLimit{ReadCapacityUnits: 100})
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String("user_logs_1"),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+		},
+	}
+	_, err := client.client.Query(ctx, input)
+	assert.NoError(t, err)
+	assert.Eq(t, input.ReturnConsumedCapacity, types.ReturnConsumedCapacity(""))
+}
@@ -1,10 +1,39 @@
 package goddb
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// strict decode 에서 struct 에 없는 attribute
+var ErrUnknownAttribute = errors.New("unknown attribute")
+
+// 몇번째 item 의 어떤 attribute 에서 실패했는지
+type DecodeError struct {
+	Index     int
+	Attribute string // 찾지 못하면 ""
+	Err       error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Attribute == "" {
+		return fmt.Sprintf("decode item[%d]: %v", e.Index, e.Err)
+	}
+
+	return fmt.Sprintf("decode item[%d] attribute %q: %v", e.Index, e.Attribute, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// 단건 결과 타입 변환 (에러 무시, UnmarshalMap 권장)
 func MarshalMap[T any](item map[string]types.AttributeValue) T {
 
 	var result T
@@ -13,9 +42,10 @@ func MarshalMap[T any](item map[string]types.AttributeValue) T {
 	return result
 }
 
+// 복수 결과 타입 변환 (에러 무시, UnmarshalMaps 권장)
 func MarshalMaps[T any](item []map[string]types.AttributeValue) []T {
 
-	var result []T
+	result := make([]T, 0, len(item))
 
 	for _, v := range item {
 		result = append(result, MarshalMap[T](v))
@@ -23,3 +53,180 @@ func MarshalMaps[T any](item []map[string]types.AttributeValue) []T {
 
 	return result
 }
+
+func UnmarshalMap[T any](item map[string]types.AttributeValue) (T, error) {
+	return decode[T](0, item, decodeOptions{})
+}
+
+func UnmarshalMaps[T any](items []map[string]types.AttributeValue) ([]T, error) {
+	return decodeAll[T](items, decodeOptions{})
+}
+
+// struct 에 없는 attribute 가 있으면 ErrUnknownAttribute
+func UnmarshalMapStrict[T any](item map[string]types.AttributeValue) (T, error) {
+	return decode[T](0, item, decodeOptions{strict: true})
+}
+
+func UnmarshalMapsStrict[T any](items []map[string]types.AttributeValue) ([]T, error) {
+	return decodeAll[T](items, decodeOptions{strict: true})
+}
+
+// decode 설정 (zero value 는 strict 아님 + PK / SK)
+type decodeOptions struct {
+	strict bool          // struct 에 없는 attribute 가 있으면 에러
+	keys   keyAttributes // key template 을 채울 테이블 key 이름
+}
+
+func (c DDBClient) decodeOptions(tableName string) decodeOptions {
+	return decodeOptions{strict: c.strict, keys: c.keyAttributes(tableName)}
+}
+
+func decodeAll[T any](items []map[string]types.AttributeValue, opts decodeOptions) ([]T, error) {
+	result := make([]T, 0, len(items))
+
+	for i, item := range items {
+		v, err := decode[T](i, item, opts)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+func decode[T any](index int, item map[string]types.AttributeValue, opts decodeOptions) (T, error) {
+	var result T
+
+	if err := decodeInto(index, item, opts, reflect.ValueOf(&result)); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return result, nil
+}
+
+// out 은 decode 할 값의 pointer
+func decodeInto(index int, item map[string]types.AttributeValue, opts decodeOptions, out reflect.Value) error {
+	t := out.Type().Elem()
+
+	names := opts.keys
+	if names.pk == "" {
+		names = DDBTableParams{}.keyAttributes()
+	}
+
+	keys, err := keyTemplatesOf(t)
+	if err != nil {
+		return &DecodeError{Index: index, Err: err}
+	}
+
+	if opts.strict {
+		if known, ok := attributeNames(t); ok {
+			// key template 으로 만든 attribute 는 필드로 다시 채워짐, EntityType 은 gdrm 이 기록
+			known[EntityTypeAttribute] = struct{}{}
+			if keys != nil {
+				for _, kt := range keys.templates {
+					known[names.of(kt.attribute)] = struct{}{}
+				}
+			}
+
+			for _, name := range sortedAttributeNames(item) {
+				if _, ok := known[name]; !ok {
+					return &DecodeError{Index: index, Attribute: name, Err: ErrUnknownAttribute}
+				}
+			}
+		}
+	}
+
+	if err := attributevalue.UnmarshalMap(item, out.Interface()); err != nil {
+		return &DecodeError{Index: index, Attribute: failedAttribute(t, item), Err: err}
+	}
+
+	if keys != nil {
+		v := out.Elem()
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct {
+			keys.fill(v, item, names)
+		}
+	}
+
+	return nil
+}
+
+// attribute 를 하나씩 decode 해서 실패한 attribute 를 찾음 (에러일때만 호출)
+func failedAttribute(t reflect.Type, item map[string]types.AttributeValue) string {
+	for _, name := range sortedAttributeNames(item) {
+		probe := reflect.New(t).Interface()
+		if err := attributevalue.UnmarshalMap(map[string]types.AttributeValue{name: item[name]}, probe); err != nil {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func sortedAttributeNames(item map[string]types.AttributeValue) []string {
+	names := make([]string, 0, len(item))
+	for name := range item {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// struct 의 dynamodbav attribute 이름 목록 (struct 가 아니면 false)
+func attributeNames(t reflect.Type) (map[string]struct{}, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	names := map[string]struct{}{}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || len(field.Index) > 1 && !embeddedVisible(t, field.Index) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("dynamodbav"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// tag 없는 embedded struct 는 필드가 펼쳐짐
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+
+	return names, true
+}
+
+// 상위 embedded struct 가 tag 로 이름이 붙었거나 "-" 면 하위 필드는 펼쳐지지 않음
+func embeddedVisible(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		field := t.Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("dynamodbav"), ","); name != "" {
+			return false
+		}
+		t = field.Type
+	}
+
+	return true
+}
@@ -0,0 +1,136 @@
+package goddb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+type Audit struct {
+	CreatedBy string `dynamodbav:"CreatedBy"`
+}
+
+type AuditedMessage struct {
+	Message
+	Audit    `dynamodbav:"Audit"`
+	Internal string `dynamodbav:"-"`
+}
+
+func Test_DDBUnmarshal(t *testing.T) {
+
+	valid := map[string]types.AttributeValue{
+		"PK":   &types.AttributeValueMemberS{Value: "USER#1"},
+		"SK":   &types.AttributeValueMemberS{Value: "#PROFILE"},
+		"Name": &types.AttributeValueMemberS{Value: "leedonggyu"},
+		"Age":  &types.AttributeValueMemberN{Value: "30"},
+	}
+
+	invalid := map[string]types.AttributeValue{
+		"PK":  &types.AttributeValueMemberS{Value: "USER#2"},
+		"Age": &types.AttributeValueMemberS{Value: "thirty"},
+	}
+
+	t.Run("1. 단건 / 타입 불일치 attribute", func(t *testing.T) {
+		message, err := UnmarshalMap[Message](valid)
+		assert.NoError(t, err)
+		assert.Eq(t, message.Age, 30)
+
+		_, err = UnmarshalMap[Message](invalid)
+
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Eq(t, decodeErr.Attribute, "Age")
+		assert.Eq(t, decodeErr.Index, 0)
+	})
+
+	t.Run("2. 복수 / 실패한 item index", func(t *testing.T) {
+		messages, err := UnmarshalMaps[Message](nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, messages)
+		assert.Len(t, messages, 0)
+		assert.NotNil(t, MarshalMaps[Message](nil))
+
+		_, err = UnmarshalMaps[Message]([]map[string]types.AttributeValue{valid, invalid})
+
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Eq(t, decodeErr.Index, 1)
+		assert.Eq(t, decodeErr.Attribute, "Age")
+	})
+
+	t.Run("3. strict (struct 에 없는 attribute)", func(t *testing.T) {
+		item := map[string]types.AttributeValue{
+			"PK":      &types.AttributeValueMemberS{Value: "USER#1"},
+			"Name":    &types.AttributeValueMemberS{Value: "leedonggyu"},
+			"Audit":   &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+			"Extra":   &types.AttributeValueMemberS{Value: "x"},
+			"Deleted": &types.AttributeValueMemberBOOL{Value: true},
+		}
+
+		_, err := UnmarshalMap[AuditedMessage](item)
+		assert.NoError(t, err)
+
+		_, err = UnmarshalMapStrict[AuditedMessage](item)
+		assert.True(t, errors.Is(err, ErrUnknownAttribute))
+
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Eq(t, decodeErr.Attribute, "Deleted")
+
+		delete(item, "Extra")
+		delete(item, "Deleted")
+		_, err = UnmarshalMapStrict[AuditedMessage](item)
+		assert.NoError(t, err)
+
+		// map 은 strict 대상 아님
+		_, err = UnmarshalMapStrict[map[string]any](valid)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_DDBGenericGet(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	scenarioInsertOrders(t, "user_logs_1", 3)
+	assert.NoError(t, client.Insert(ctx, "user_logs_1", Message{PK: "USER#1", SK: "ORDER#004", Name: "not an order"}))
+
+	t.Run("1. Get[T] / Query[T]", func(t *testing.T) {
+		order, err := Get[Order](ctx, client, "user_logs_1", "USER#1", "ORDER#002")
+		assert.NoError(t, err)
+		assert.Eq(t, order.Amount, 200)
+
+		_, err = Get[Order](ctx, client, "user_logs_1", "USER#1", "ORDER#999")
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		orders, err := Query[Order](ctx, client, "user_logs_1", 0, userOrdersParams)
+		assert.NoError(t, err)
+		assert.Len(t, orders, 4)
+	})
+
+	t.Run("2. strict decode / iterator 에서 decode 에러 전달", func(t *testing.T) {
+		client.WithStrictDecode(true)
+		defer client.WithStrictDecode(false)
+
+		_, err := Query[Order](ctx, client, "user_logs_1", 0, userOrdersParams)
+
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Eq(t, decodeErr.Index, 3)
+
+		var valid, invalid int
+		for _, err := range FindSeq[Order](ctx, client, "user_logs_1", 2, userOrdersParams) {
+			if err != nil {
+				invalid++
+				continue
+			}
+			valid++
+		}
+		assert.Eq(t, valid, 3)
+		assert.Eq(t, invalid, 1)
+	})
+}
@@ -0,0 +1,796 @@
+package memdb
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Condition / KeyCondition expression 파서
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenName  // #name
+	tokenValue // :value
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenDot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{tokenLBracket, "["})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{tokenRBracket, "]"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case r == '.':
+			tokens = append(tokens, token{tokenDot, "."})
+			i++
+		case r == '+' || r == '-':
+			tokens = append(tokens, token{tokenOperator, string(r)})
+			i++
+
+		case r == '=':
+			tokens = append(tokens, token{tokenOperator, "="})
+			i++
+		case r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+				i++
+			}
+			tokens = append(tokens, token{tokenOperator, op})
+			i++
+
+		case r == '#' || r == ':':
+			j := i + 1
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("invalid placeholder at position %d", i)
+			}
+			kind := tokenName
+			if r == ':' {
+				kind = tokenValue
+			}
+			tokens = append(tokens, token{kind, string(runes[i:j])})
+			i = j
+
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[i:j])})
+			i = j
+
+		case isIdentRune(r):
+			j := i
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[i:j])})
+			i = j
+
+		default:
+			return nil, fmt.Errorf("invalid character %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// AST
+
+type node interface{}
+
+type pathPart struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+type pathNode struct {
+	parts []pathPart
+}
+
+type valueNode struct {
+	name string
+}
+
+type funcNode struct {
+	name string
+	args []node
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+type betweenNode struct {
+	target, low, high node
+}
+
+type inNode struct {
+	target node
+	list   []node
+}
+
+type logicNode struct {
+	op          string
+	left, right node
+}
+
+type notNode struct {
+	inner node
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	names  map[string]string
+}
+
+func newParser(expression string, names map[string]string) (*parser, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, validationError(fmt.Sprintf("Invalid expression: %s", err))
+	}
+
+	return &parser{tokens: tokens, names: names}, nil
+}
+
+func parseCondition(expression string, names map[string]string) (node, error) {
+	p, err := newParser(expression, names)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("unexpected token %q", p.peek().text)
+	}
+
+	return n, nil
+}
+
+// ProjectionExpression (콤마로 구분된 document path 목록)
+func parseProjection(expression string, names map[string]string) ([]pathNode, error) {
+	p, err := newParser(expression, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []pathNode
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path.(pathNode))
+
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("unexpected token %q", p.peek().text)
+	}
+
+	return paths, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t := p.next()
+	if t.kind != kind {
+		return p.errorf("expected %q but got %q", text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return validationError("Invalid expression: " + fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{op: "OR", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{op: "AND", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner: inner}, nil
+	}
+
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (node, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokenOperator && isComparator(t.text):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: t.text, left: left, right: right}, nil
+
+	case p.isKeyword("BETWEEN"):
+		p.next()
+		low, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isKeyword("AND") {
+			return nil, p.errorf("expected AND in BETWEEN")
+		}
+		p.next()
+		high, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return betweenNode{target: left, low: low, high: high}, nil
+
+	case p.isKeyword("IN"):
+		p.next()
+		if err := p.expect(tokenLParen, "("); err != nil {
+			return nil, err
+		}
+		var list []node
+		for {
+			operand, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, operand)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inNode{target: left, list: list}, nil
+	}
+
+	// 단독으로 쓸 수 있는 건 boolean 함수 뿐
+	if fn, ok := left.(funcNode); ok && fn.name != "size" {
+		return fn, nil
+	}
+
+	return nil, p.errorf("expected a condition near %q", t.text)
+}
+
+func isComparator(op string) bool {
+	switch op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.peek()
+
+	switch t.kind {
+	case tokenValue:
+		p.next()
+		return valueNode{name: t.text}, nil
+
+	case tokenIdent:
+		if p.tokens[p.pos+1].kind == tokenLParen {
+			return p.parseFunction()
+		}
+		return p.parsePath()
+
+	case tokenName:
+		return p.parsePath()
+	}
+
+	return nil, p.errorf("unexpected token %q", t.text)
+}
+
+func (p *parser) parseFunction() (node, error) {
+	name := p.next().text
+	p.next() // (
+
+	var args []node
+	for p.peek().kind != tokenRParen {
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	return funcNode{name: name, args: args}, nil
+}
+
+func (p *parser) parsePath() (node, error) {
+	var parts []pathPart
+
+	for {
+		t := p.next()
+		switch t.kind {
+		case tokenIdent:
+			parts = append(parts, pathPart{name: t.text})
+		case tokenName:
+			name, ok := p.names[t.text]
+			if !ok {
+				return nil, validationError(fmt.Sprintf("An expression attribute name used in the document path is not defined; attribute name: %s", t.text))
+			}
+			parts = append(parts, pathPart{name: name})
+		default:
+			return nil, p.errorf("expected attribute name but got %q", t.text)
+		}
+
+		for p.peek().kind == tokenLBracket {
+			p.next()
+			n := p.next()
+			if n.kind != tokenNumber {
+				return nil, p.errorf("expected list index but got %q", n.text)
+			}
+			index, _ := strconv.Atoi(n.text)
+			if err := p.expect(tokenRBracket, "]"); err != nil {
+				return nil, err
+			}
+			parts = append(parts, pathPart{index: index, isIndex: true})
+		}
+
+		if p.peek().kind != tokenDot {
+			return pathNode{parts: parts}, nil
+		}
+		p.next()
+	}
+}
+
+// 평가
+
+type evaluator struct {
+	item   map[string]types.AttributeValue
+	values map[string]types.AttributeValue
+}
+
+func evaluateCondition(n node, item map[string]types.AttributeValue, values map[string]types.AttributeValue) (bool, error) {
+	e := evaluator{item: item, values: values}
+	return e.condition(n)
+}
+
+func (e evaluator) condition(n node) (bool, error) {
+	switch v := n.(type) {
+	case logicNode:
+		left, err := e.condition(v.left)
+		if err != nil {
+			return false, err
+		}
+		right, err := e.condition(v.right)
+		if err != nil {
+			return false, err
+		}
+		if v.op == "AND" {
+			return left && right, nil
+		}
+		return left || right, nil
+
+	case notNode:
+		inner, err := e.condition(v.inner)
+		return !inner, err
+
+	case compareNode:
+		left, lok, err := e.operand(v.left)
+		if err != nil {
+			return false, err
+		}
+		right, rok, err := e.operand(v.right)
+		if err != nil {
+			return false, err
+		}
+		if !lok || !rok {
+			return v.op == "<>" && lok != rok, nil
+		}
+		return compare(v.op, left, right), nil
+
+	case betweenNode:
+		target, ok, err := e.operand(v.target)
+		if err != nil || !ok {
+			return false, err
+		}
+		low, _, err := e.operand(v.low)
+		if err != nil {
+			return false, err
+		}
+		high, _, err := e.operand(v.high)
+		if err != nil {
+			return false, err
+		}
+		return compare(">=", target, low) && compare("<=", target, high), nil
+
+	case inNode:
+		target, ok, err := e.operand(v.target)
+		if err != nil || !ok {
+			return false, err
+		}
+		for _, candidate := range v.list {
+			value, ok, err := e.operand(candidate)
+			if err != nil {
+				return false, err
+			}
+			if ok && equalValues(target, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case funcNode:
+		return e.function(v)
+	}
+
+	return false, validationError("Invalid condition expression")
+}
+
+func (e evaluator) function(fn funcNode) (bool, error) {
+	args := make([]types.AttributeValue, len(fn.args))
+	found := make([]bool, len(fn.args))
+	for i, arg := range fn.args {
+		value, ok, err := e.operand(arg)
+		if err != nil {
+			return false, err
+		}
+		args[i], found[i] = value, ok
+	}
+
+	arity := func(n int) error {
+		if len(args) != n {
+			return validationError(fmt.Sprintf("Invalid number of arguments for function %s", fn.name))
+		}
+		if _, ok := fn.args[0].(pathNode); !ok {
+			return validationError(fmt.Sprintf("The first argument of %s must be an attribute path", fn.name))
+		}
+		return nil
+	}
+
+	switch fn.name {
+	case "attribute_exists":
+		if err := arity(1); err != nil {
+			return false, err
+		}
+		return found[0], nil
+
+	case "attribute_not_exists":
+		if err := arity(1); err != nil {
+			return false, err
+		}
+		return !found[0], nil
+
+	case "attribute_type":
+		if err := arity(2); err != nil {
+			return false, err
+		}
+		want, ok := args[1].(*types.AttributeValueMemberS)
+		if !found[0] || !ok {
+			return false, nil
+		}
+		return typeName(args[0]) == want.Value, nil
+
+	case "begins_with":
+		if err := arity(2); err != nil {
+			return false, err
+		}
+		if !found[0] || !found[1] {
+			return false, nil
+		}
+		switch target := args[0].(type) {
+		case *types.AttributeValueMemberS:
+			prefix, ok := args[1].(*types.AttributeValueMemberS)
+			return ok && strings.HasPrefix(target.Value, prefix.Value), nil
+		case *types.AttributeValueMemberB:
+			prefix, ok := args[1].(*types.AttributeValueMemberB)
+			return ok && bytes.HasPrefix(target.Value, prefix.Value), nil
+		}
+		return false, nil
+
+	case "contains":
+		if err := arity(2); err != nil {
+			return false, err
+		}
+		if !found[0] || !found[1] {
+			return false, nil
+		}
+		return contains(args[0], args[1]), nil
+	}
+
+	return false, validationError(fmt.Sprintf("Invalid function name; function: %s", fn.name))
+}
+
+func (e evaluator) operand(n node) (types.AttributeValue, bool, error) {
+	switch v := n.(type) {
+	case valueNode:
+		value, ok := e.values[v.name]
+		if !ok {
+			return nil, false, validationError(fmt.Sprintf("An expression attribute value used in expression is not defined; attribute value: %s", v.name))
+		}
+		return value, true, nil
+
+	case pathNode:
+		value, ok := resolvePath(e.item, v.parts)
+		return value, ok, nil
+
+	case funcNode:
+		if v.name != "size" || len(v.args) != 1 {
+			return nil, false, validationError(fmt.Sprintf("Function %s can not be used as an operand", v.name))
+		}
+		value, ok, err := e.operand(v.args[0])
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		size, ok := sizeOf(value)
+		if !ok {
+			return nil, false, nil
+		}
+		return &types.AttributeValueMemberN{Value: strconv.Itoa(size)}, true, nil
+	}
+
+	return nil, false, validationError("Invalid operand")
+}
+
+func resolvePath(item map[string]types.AttributeValue, parts []pathPart) (types.AttributeValue, bool) {
+	var current types.AttributeValue = &types.AttributeValueMemberM{Value: item}
+
+	for _, part := range parts {
+		switch v := current.(type) {
+		case *types.AttributeValueMemberM:
+			if part.isIndex {
+				return nil, false
+			}
+			next, ok := v.Value[part.name]
+			if !ok {
+				return nil, false
+			}
+			current = next
+
+		case *types.AttributeValueMemberL:
+			if !part.isIndex || part.index >= len(v.Value) {
+				return nil, false
+			}
+			current = v.Value[part.index]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func compare(op string, left, right types.AttributeValue) bool {
+	switch op {
+	case "=":
+		return equalValues(left, right)
+	case "<>":
+		return !equalValues(left, right)
+	}
+
+	cmp, ok := compareValues(left, right)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+func contains(target, operand types.AttributeValue) bool {
+	switch v := target.(type) {
+	case *types.AttributeValueMemberS:
+		s, ok := operand.(*types.AttributeValueMemberS)
+		return ok && strings.Contains(v.Value, s.Value)
+	case *types.AttributeValueMemberB:
+		b, ok := operand.(*types.AttributeValueMemberB)
+		return ok && bytes.Contains(v.Value, b.Value)
+	case *types.AttributeValueMemberSS:
+		_, ok := stringSet(v.Value)[keyString(operand)]
+		return ok
+	case *types.AttributeValueMemberNS:
+		_, ok := numberSet(v.Value)[keyString(operand)]
+		return ok
+	case *types.AttributeValueMemberBS:
+		_, ok := binarySet(v.Value)[keyString(operand)]
+		return ok
+	case *types.AttributeValueMemberL:
+		for _, e := range v.Value {
+			if equalValues(e, operand) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func sizeOf(av types.AttributeValue) (int, bool) {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberB:
+		return len(v.Value), true
+	case *types.AttributeValueMemberSS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberNS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberBS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberL:
+		return len(v.Value), true
+	case *types.AttributeValueMemberM:
+		return len(v.Value), true
+	}
+
+	return 0, false
+}
+
+func typeName(av types.AttributeValue) string {
+	switch av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberM:
+		return "M"
+	}
+
+	return ""
+}
@@ -0,0 +1,400 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	maxBatchWriteItems = 25
+	maxBatchGetItems   = 100
+)
+
+func (db *DB) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.itemKey(params.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.validateIndexKeys(params.Item); err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues, old, params.ReturnValuesOnConditionCheckFailure); err != nil {
+		return nil, err
+	}
+
+	t.items[key] = copyItem(params.Item)
+
+	output := &dynamodb.PutItemOutput{}
+	if params.ReturnValues == types.ReturnValueAllOld && old != nil {
+		output.Attributes = copyItem(old)
+	}
+
+	return output, nil
+}
+
+func (db *DB) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.GetItemOutput{
+		Item: copyItem(t.items[key]),
+	}, nil
+}
+
+func (db *DB) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues, old, params.ReturnValuesOnConditionCheckFailure); err != nil {
+		return nil, err
+	}
+
+	delete(t.items, key)
+
+	output := &dynamodb.DeleteItemOutput{}
+	if params.ReturnValues == types.ReturnValueAllOld && old != nil {
+		output.Attributes = copyItem(old)
+	}
+
+	return output, nil
+}
+
+func (db *DB) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues, old, params.ReturnValuesOnConditionCheckFailure); err != nil {
+		return nil, err
+	}
+
+	// UpdateExpression 이 없으면 key 만 있는 item 생성
+	item := copyItem(old)
+	var updated []string
+	if aws.ToString(params.UpdateExpression) != "" {
+		if item, err = t.update(old, params.Key, *params.UpdateExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues); err != nil {
+			return nil, err
+		}
+
+		actions, _ := parseUpdate(*params.UpdateExpression, params.ExpressionAttributeNames)
+		for _, action := range actions {
+			updated = append(updated, action.path.parts[0].name)
+		}
+	} else if item == nil {
+		item = copyItem(params.Key)
+	}
+
+	t.items[key] = item
+
+	output := &dynamodb.UpdateItemOutput{}
+	switch params.ReturnValues {
+	case types.ReturnValueAllOld:
+		if old != nil {
+			output.Attributes = copyItem(old)
+		}
+	case types.ReturnValueAllNew:
+		output.Attributes = copyItem(item)
+	case types.ReturnValueUpdatedOld:
+		output.Attributes = pick(old, updated)
+	case types.ReturnValueUpdatedNew:
+		output.Attributes = pick(item, updated)
+	}
+
+	return output, nil
+}
+
+// UPDATED_OLD / UPDATED_NEW 는 update 한 top-level attribute 만 반환
+func pick(item map[string]types.AttributeValue, names []string) map[string]types.AttributeValue {
+	picked := map[string]types.AttributeValue{}
+	for _, name := range names {
+		if value, ok := item[name]; ok {
+			picked[name] = copyValue(value)
+		}
+	}
+
+	if len(picked) == 0 {
+		return nil
+	}
+
+	return picked
+}
+
+func (db *DB) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	total := 0
+	for _, requests := range params.RequestItems {
+		total += len(requests)
+	}
+	if total == 0 || total > maxBatchWriteItems {
+		return nil, validationError(fmt.Sprintf("Too many items requested for the BatchWriteItem call: %d", total))
+	}
+
+	// 전부 검증한 뒤 반영
+	type write struct {
+		t    *table
+		key  string
+		item map[string]types.AttributeValue
+	}
+
+	var writes []write
+	seen := map[string]struct{}{}
+
+	for tableName, requests := range params.RequestItems {
+		t, err := db.table(tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, request := range requests {
+			var (
+				key  string
+				item map[string]types.AttributeValue
+			)
+
+			switch {
+			case request.PutRequest != nil:
+				key, err = t.itemKey(request.PutRequest.Item)
+				if err == nil {
+					err = t.validateIndexKeys(request.PutRequest.Item)
+				}
+				item = request.PutRequest.Item
+			case request.DeleteRequest != nil:
+				key, err = t.lookupKey(request.DeleteRequest.Key)
+			default:
+				err = validationError("WriteRequest must contain PutRequest or DeleteRequest")
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := seen[tableName+"/"+key]; ok {
+				return nil, validationError("Provided list of item keys contains duplicates")
+			}
+			seen[tableName+"/"+key] = struct{}{}
+
+			writes = append(writes, write{t: t, key: key, item: item})
+		}
+	}
+
+	for _, w := range writes {
+		if w.item == nil {
+			delete(w.t.items, w.key)
+			continue
+		}
+		w.t.items[w.key] = copyItem(w.item)
+	}
+
+	return &dynamodb.BatchWriteItemOutput{
+		UnprocessedItems: map[string][]types.WriteRequest{},
+	}, nil
+}
+
+func (db *DB) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	total := 0
+	for _, keysAndAttributes := range params.RequestItems {
+		total += len(keysAndAttributes.Keys)
+	}
+	if total == 0 || total > maxBatchGetItems {
+		return nil, validationError(fmt.Sprintf("Too many items requested for the BatchGetItem call: %d", total))
+	}
+
+	responses := map[string][]map[string]types.AttributeValue{}
+
+	for tableName, keysAndAttributes := range params.RequestItems {
+		t, err := db.table(tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		var projection []pathNode
+		if aws.ToString(keysAndAttributes.ProjectionExpression) != "" {
+			if projection, err = parseProjection(*keysAndAttributes.ProjectionExpression, keysAndAttributes.ExpressionAttributeNames); err != nil {
+				return nil, err
+			}
+		}
+
+		seen := map[string]struct{}{}
+		items := []map[string]types.AttributeValue{}
+
+		for _, k := range keysAndAttributes.Keys {
+			key, err := t.lookupKey(k)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := seen[key]; ok {
+				return nil, validationError("Provided list of item keys contains duplicates")
+			}
+			seen[key] = struct{}{}
+
+			item, ok := t.items[key]
+			if !ok {
+				continue
+			}
+			if projection != nil {
+				item = projectPaths(item, projection)
+			}
+			items = append(items, copyItem(item))
+		}
+
+		responses[tableName] = items
+	}
+
+	return &dynamodb.BatchGetItemOutput{
+		Responses:       responses,
+		UnprocessedKeys: map[string]types.KeysAndAttributes{},
+	}, nil
+}
+
+// item 에서 primary key 를 뽑아 저장소 key 로 변환 (값에 구분자가 있어도 겹치지 않도록 quote)
+func (t *table) itemKey(item map[string]types.AttributeValue) (string, error) {
+	key := ""
+
+	for _, name := range t.keyNames() {
+		value, ok := item[name]
+		if !ok {
+			return "", validationError(fmt.Sprintf("One of the required keys was not given a value: %s", name))
+		}
+
+		if scalarType(value) != t.attrTypes[name] {
+			return "", validationError(fmt.Sprintf("Type mismatch for key %s expected: %s", name, t.attrTypes[name]))
+		}
+
+		if s, ok := value.(*types.AttributeValueMemberS); ok && s.Value == "" {
+			return "", validationError(fmt.Sprintf("One or more parameter values are not valid. The AttributeValue for a key attribute cannot contain an empty string value. Key: %s", name))
+		}
+
+		key += strconv.Quote(keyString(value))
+	}
+
+	return key, nil
+}
+
+// GetItem / DeleteItem 의 Key 는 key attribute 만 정확히 가지고 있어야 함
+func (t *table) lookupKey(key map[string]types.AttributeValue) (string, error) {
+	if len(key) != len(t.keyNames()) {
+		return "", validationError("The provided key element does not match the schema")
+	}
+
+	return t.itemKey(key)
+}
+
+// index key attribute 는 없어도 되지만(sparse index) 있으면 정의된 타입이어야 함
+func (t *table) validateIndexKeys(item map[string]types.AttributeValue) error {
+	for _, idx := range t.indexes {
+		for _, name := range []string{idx.spec.hashKey, idx.spec.rangeKey} {
+			value, ok := item[name]
+			if name == "" || !ok {
+				continue
+			}
+
+			if scalarType(value) != t.attrTypes[name] {
+				return validationError(fmt.Sprintf("One or more parameter values were invalid: Type mismatch for Index Key %s Expected: %s IndexName: %s", name, t.attrTypes[name], idx.name))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *table) keyNames() []string {
+	if t.rangeKey == "" {
+		return []string{t.hashKey}
+	}
+
+	return []string{t.hashKey, t.rangeKey}
+}
+
+func (t *table) primaryKey(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	key := map[string]types.AttributeValue{}
+	for _, name := range t.keyNames() {
+		key[name] = copyValue(item[name])
+	}
+
+	return key
+}
+
+func checkCondition(expression *string, names map[string]string, values map[string]types.AttributeValue, existing map[string]types.AttributeValue, onFailure types.ReturnValuesOnConditionCheckFailure) error {
+	if aws.ToString(expression) == "" {
+		return nil
+	}
+
+	n, err := parseCondition(*expression, names)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		existing = map[string]types.AttributeValue{}
+	}
+
+	ok, err := evaluateCondition(n, existing, values)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		condErr := &types.ConditionalCheckFailedException{
+			Message: aws.String("The conditional request failed"),
+		}
+		if onFailure == types.ReturnValuesOnConditionCheckFailureAllOld && len(existing) > 0 {
+			condErr.Item = copyItem(existing)
+		}
+		return condErr
+	}
+
+	return nil
+}
@@ -0,0 +1,545 @@
+// memdb 는 gdrm 이 사용하는 DynamoDB operation 을 프로세스 안에서 흉내내는 in-memory 백엔드
+// 네트워크 / AWS 계정 없이 gdrm.NewDDB(memdb.New()) 로 테스트를 돌릴 수 있음
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	region    = "local"
+	accountID = "000000000000"
+)
+
+type DB struct {
+	mu     sync.RWMutex
+	tables map[string]*table
+	seq    int
+}
+
+type table struct {
+	name     string
+	hashKey  string
+	rangeKey string
+
+	attrTypes            map[string]types.ScalarAttributeType
+	keySchema            []types.KeySchemaElement
+	attributeDefinitions []types.AttributeDefinition
+	billingMode          types.BillingMode
+	throughput           types.ProvisionedThroughput
+
+	indexes map[string]*index
+
+	ttlAttribute string // TTL 이 켜져있으면 attribute 이름 (만료된 item 삭제는 흉내내지 않음)
+
+	id      string
+	created time.Time
+
+	items map[string]map[string]types.AttributeValue
+}
+
+// GSI / LSI (item 은 따로 저장하지 않고 조회 시점에 table item 에서 계산)
+type index struct {
+	name       string
+	spec       keySpec
+	keySchema  []types.KeySchemaElement
+	projection types.Projection
+	throughput types.ProvisionedThroughput
+	local      bool
+}
+
+func New() *DB {
+	return &DB{
+		tables: map[string]*table{},
+	}
+}
+
+func (db *DB) CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tableName := aws.ToString(params.TableName)
+	if tableName == "" {
+		return nil, validationError("TableName is required")
+	}
+
+	if _, ok := db.tables[tableName]; ok {
+		return nil, &types.ResourceInUseException{
+			Message: aws.String(fmt.Sprintf("Table already exists: %s", tableName)),
+		}
+	}
+
+	attrTypes := map[string]types.ScalarAttributeType{}
+	for _, def := range params.AttributeDefinitions {
+		attrTypes[aws.ToString(def.AttributeName)] = def.AttributeType
+	}
+
+	hashKey, rangeKey, err := parseKeySchema(params.KeySchema, attrTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	billingMode := params.BillingMode
+	if billingMode == "" {
+		billingMode = types.BillingModeProvisioned
+	}
+
+	var throughput types.ProvisionedThroughput
+	if billingMode == types.BillingModeProvisioned {
+		if params.ProvisionedThroughput == nil {
+			return nil, validationError("No provisioned throughput specified for the table")
+		}
+		throughput = *params.ProvisionedThroughput
+	}
+
+	indexes := map[string]*index{}
+	for _, gsi := range params.GlobalSecondaryIndexes {
+		idx, err := newIndex(aws.ToString(gsi.IndexName), gsi.KeySchema, gsi.Projection, attrTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		if billingMode == types.BillingModeProvisioned {
+			if gsi.ProvisionedThroughput == nil {
+				return nil, validationError(fmt.Sprintf("No provisioned throughput specified for the global secondary index %s", idx.name))
+			}
+			idx.throughput = *gsi.ProvisionedThroughput
+		}
+
+		if _, ok := indexes[idx.name]; ok {
+			return nil, validationError(fmt.Sprintf("Duplicate index name: %s", idx.name))
+		}
+		indexes[idx.name] = idx
+	}
+
+	for _, lsi := range params.LocalSecondaryIndexes {
+		idx, err := newIndex(aws.ToString(lsi.IndexName), lsi.KeySchema, lsi.Projection, attrTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		if rangeKey == "" {
+			return nil, validationError("One or more parameter values were invalid: Table KeySchema does not have a range key, which is required when specifying a LocalSecondaryIndex")
+		}
+		if idx.spec.hashKey != hashKey || idx.spec.rangeKey == "" {
+			return nil, validationError(fmt.Sprintf("One or more parameter values were invalid: Index KeySchema must have the same hash key as the table and a range key: %s", idx.name))
+		}
+
+		if _, ok := indexes[idx.name]; ok {
+			return nil, validationError(fmt.Sprintf("Duplicate index name: %s", idx.name))
+		}
+		idx.local = true
+		indexes[idx.name] = idx
+	}
+
+	db.seq++
+	t := &table{
+		name:                 tableName,
+		hashKey:              hashKey,
+		rangeKey:             rangeKey,
+		attrTypes:            attrTypes,
+		keySchema:            params.KeySchema,
+		attributeDefinitions: params.AttributeDefinitions,
+		billingMode:          billingMode,
+		throughput:           throughput,
+		indexes:              indexes,
+		id:                   fmt.Sprintf("00000000-0000-0000-0000-%012d", db.seq),
+		created:              time.Now(),
+		items:                map[string]map[string]types.AttributeValue{},
+	}
+
+	db.tables[tableName] = t
+
+	return &dynamodb.CreateTableOutput{
+		TableDescription: t.describe(),
+	}, nil
+}
+
+func (db *DB) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.DescribeTableOutput{
+		Table: t.describe(),
+	}, nil
+}
+
+func (db *DB) ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	names := make([]string, 0, len(db.tables))
+	for name := range db.tables {
+		if params.ExclusiveStartTableName != nil && name <= *params.ExclusiveStartTableName {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	output := &dynamodb.ListTablesOutput{}
+	if params.Limit != nil && int(*params.Limit) < len(names) {
+		names = names[:*params.Limit]
+		output.LastEvaluatedTableName = aws.String(names[len(names)-1])
+	}
+	output.TableNames = names
+
+	return output, nil
+}
+
+func (db *DB) DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	description := t.describe()
+	description.TableStatus = types.TableStatusDeleting
+	delete(db.tables, t.name)
+
+	return &dynamodb.DeleteTableOutput{
+		TableDescription: description,
+	}, nil
+}
+
+// billing mode / 용량 변경과 GSI 추가 / 삭제 (GSI 추가 / 삭제는 한번에 하나)
+func (db *DB) UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	// 검증이 끝난 뒤에 반영하도록 복사본에서 변경
+	attrTypes := map[string]types.ScalarAttributeType{}
+	for name, attrType := range t.attrTypes {
+		attrTypes[name] = attrType
+	}
+	attributeDefinitions := append([]types.AttributeDefinition{}, t.attributeDefinitions...)
+
+	for _, def := range params.AttributeDefinitions {
+		name := aws.ToString(def.AttributeName)
+
+		if attrType, ok := attrTypes[name]; ok {
+			if attrType != def.AttributeType {
+				return nil, validationError(fmt.Sprintf("Cannot change the type of attribute %s", name))
+			}
+			continue
+		}
+
+		attrTypes[name] = def.AttributeType
+		attributeDefinitions = append(attributeDefinitions, def)
+	}
+
+	billingMode := t.billingMode
+	if params.BillingMode != "" {
+		billingMode = params.BillingMode
+	}
+
+	throughput := t.throughput
+	switch {
+	case billingMode == types.BillingModePayPerRequest:
+		if params.ProvisionedThroughput != nil {
+			return nil, validationError("One or more parameter values were invalid: Neither ReadCapacityUnits nor WriteCapacityUnits can be specified when BillingMode is PAY_PER_REQUEST")
+		}
+		throughput = types.ProvisionedThroughput{}
+	case params.ProvisionedThroughput != nil:
+		throughput = *params.ProvisionedThroughput
+	case t.billingMode != billingMode:
+		return nil, validationError("No provisioned throughput specified for the table")
+	}
+
+	indexes := map[string]*index{}
+	for name, idx := range t.indexes {
+		copied := *idx
+		if billingMode == types.BillingModePayPerRequest {
+			copied.throughput = types.ProvisionedThroughput{}
+		}
+		indexes[name] = &copied
+	}
+
+	changed := 0 // 추가 + 삭제한 GSI 수
+	for _, update := range params.GlobalSecondaryIndexUpdates {
+		switch {
+		case update.Create != nil:
+			changed++
+
+			idx, err := newIndex(aws.ToString(update.Create.IndexName), update.Create.KeySchema, update.Create.Projection, attrTypes)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := indexes[idx.name]; ok {
+				return nil, validationError(fmt.Sprintf("Index already exists: %s", idx.name))
+			}
+
+			if billingMode == types.BillingModeProvisioned {
+				if update.Create.ProvisionedThroughput == nil {
+					return nil, validationError(fmt.Sprintf("No provisioned throughput specified for the global secondary index %s", idx.name))
+				}
+				idx.throughput = *update.Create.ProvisionedThroughput
+			}
+			indexes[idx.name] = idx
+
+		case update.Delete != nil:
+			changed++
+
+			name := aws.ToString(update.Delete.IndexName)
+			if idx, ok := indexes[name]; !ok || idx.local {
+				return nil, &types.ResourceNotFoundException{
+					Message: aws.String(fmt.Sprintf("Requested resource not found: Index: %s not found", name)),
+				}
+			}
+			delete(indexes, name)
+
+		case update.Update != nil:
+			name := aws.ToString(update.Update.IndexName)
+			idx, ok := indexes[name]
+			if !ok || idx.local {
+				return nil, &types.ResourceNotFoundException{
+					Message: aws.String(fmt.Sprintf("Requested resource not found: Index: %s not found", name)),
+				}
+			}
+			if billingMode != types.BillingModeProvisioned || update.Update.ProvisionedThroughput == nil {
+				return nil, validationError(fmt.Sprintf("Cannot update provisioned throughput of index %s", name))
+			}
+			idx.throughput = *update.Update.ProvisionedThroughput
+		}
+	}
+
+	if changed > 1 {
+		return nil, validationError("Subscriber limit exceeded: Only 1 online index can be created or deleted simultaneously per table")
+	}
+
+	// provisioned 로 바꾸면 기존 GSI 도 용량이 필요
+	for _, name := range sortedIndexNames(indexes) {
+		idx := indexes[name]
+		if billingMode == types.BillingModeProvisioned && !idx.local && idx.throughput.ReadCapacityUnits == nil {
+			return nil, validationError(fmt.Sprintf("No provisioned throughput specified for the global secondary index %s", name))
+		}
+	}
+
+	t.attrTypes = attrTypes
+	t.attributeDefinitions = attributeDefinitions
+	t.billingMode = billingMode
+	t.throughput = throughput
+	t.indexes = indexes
+
+	return &dynamodb.UpdateTableOutput{
+		TableDescription: t.describe(),
+	}, nil
+}
+
+func (db *DB) UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := params.TimeToLiveSpecification
+	if spec == nil || aws.ToString(spec.AttributeName) == "" || spec.Enabled == nil {
+		return nil, validationError("TimeToLiveSpecification requires AttributeName and Enabled")
+	}
+
+	switch {
+	case *spec.Enabled && t.ttlAttribute != "":
+		return nil, validationError("TimeToLive is already enabled")
+	case !*spec.Enabled && t.ttlAttribute == "":
+		return nil, validationError("TimeToLive is already disabled")
+	case !*spec.Enabled && t.ttlAttribute != *spec.AttributeName:
+		return nil, validationError(fmt.Sprintf("TimeToLive is enabled on attribute %s", t.ttlAttribute))
+	}
+
+	if *spec.Enabled {
+		t.ttlAttribute = *spec.AttributeName
+	} else {
+		t.ttlAttribute = ""
+	}
+
+	return &dynamodb.UpdateTimeToLiveOutput{
+		TimeToLiveSpecification: spec,
+	}, nil
+}
+
+func (db *DB) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	description := &types.TimeToLiveDescription{
+		TimeToLiveStatus: types.TimeToLiveStatusDisabled,
+	}
+	if t.ttlAttribute != "" {
+		description.TimeToLiveStatus = types.TimeToLiveStatusEnabled
+		description.AttributeName = aws.String(t.ttlAttribute)
+	}
+
+	return &dynamodb.DescribeTimeToLiveOutput{
+		TimeToLiveDescription: description,
+	}, nil
+}
+
+func (db *DB) table(tableName string) (*table, error) {
+	t, ok := db.tables[tableName]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{
+			Message: aws.String(fmt.Sprintf("Requested resource not found: Table: %s not found", tableName)),
+		}
+	}
+
+	return t, nil
+}
+
+func (t *table) describe() *types.TableDescription {
+	var size int64
+	for _, item := range t.items {
+		size += itemSize(item)
+	}
+
+	description := &types.TableDescription{
+		TableName:            aws.String(t.name),
+		TableArn:             aws.String(fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s", region, accountID, t.name)),
+		TableId:              aws.String(t.id),
+		TableStatus:          types.TableStatusActive,
+		CreationDateTime:     aws.Time(t.created),
+		ItemCount:            aws.Int64(int64(len(t.items))),
+		TableSizeBytes:       aws.Int64(size),
+		KeySchema:            t.keySchema,
+		AttributeDefinitions: t.attributeDefinitions,
+		BillingModeSummary: &types.BillingModeSummary{
+			BillingMode: t.billingMode,
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughputDescription{
+			ReadCapacityUnits:      aws.Int64(aws.ToInt64(t.throughput.ReadCapacityUnits)),
+			WriteCapacityUnits:     aws.Int64(aws.ToInt64(t.throughput.WriteCapacityUnits)),
+			NumberOfDecreasesToday: aws.Int64(0),
+		},
+	}
+
+	for _, name := range sortedIndexNames(t.indexes) {
+		idx := t.indexes[name]
+
+		if idx.local {
+			description.LocalSecondaryIndexes = append(description.LocalSecondaryIndexes, types.LocalSecondaryIndexDescription{
+				IndexName:  aws.String(idx.name),
+				IndexArn:   aws.String(aws.ToString(description.TableArn) + "/index/" + idx.name),
+				KeySchema:  idx.keySchema,
+				Projection: &idx.projection,
+			})
+			continue
+		}
+
+		description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, types.GlobalSecondaryIndexDescription{
+			IndexName:   aws.String(idx.name),
+			IndexArn:    aws.String(aws.ToString(description.TableArn) + "/index/" + idx.name),
+			IndexStatus: types.IndexStatusActive,
+			KeySchema:   idx.keySchema,
+			Projection:  &idx.projection,
+			ProvisionedThroughput: &types.ProvisionedThroughputDescription{
+				ReadCapacityUnits:      aws.Int64(aws.ToInt64(idx.throughput.ReadCapacityUnits)),
+				WriteCapacityUnits:     aws.Int64(aws.ToInt64(idx.throughput.WriteCapacityUnits)),
+				NumberOfDecreasesToday: aws.Int64(0),
+			},
+		})
+	}
+
+	return description
+}
+
+func newIndex(name string, keySchema []types.KeySchemaElement, projection *types.Projection, attrTypes map[string]types.ScalarAttributeType) (*index, error) {
+	if name == "" {
+		return nil, validationError("IndexName is required")
+	}
+
+	hashKey, rangeKey, err := parseKeySchema(keySchema, attrTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if projection == nil {
+		return nil, validationError(fmt.Sprintf("Projection is required for index %s", name))
+	}
+
+	return &index{
+		name:       name,
+		spec:       keySpec{hashKey: hashKey, rangeKey: rangeKey},
+		keySchema:  keySchema,
+		projection: *projection,
+	}, nil
+}
+
+func sortedIndexNames(indexes map[string]*index) []string {
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func parseKeySchema(keySchema []types.KeySchemaElement, attrTypes map[string]types.ScalarAttributeType) (string, string, error) {
+	var hashKey, rangeKey string
+
+	for _, key := range keySchema {
+		name := aws.ToString(key.AttributeName)
+
+		if _, ok := attrTypes[name]; !ok {
+			return "", "", validationError(fmt.Sprintf("No attribute definition for key attribute %s", name))
+		}
+
+		switch key.KeyType {
+		case types.KeyTypeHash:
+			if hashKey != "" {
+				return "", "", validationError("Too many hash keys specified")
+			}
+			hashKey = name
+		case types.KeyTypeRange:
+			if rangeKey != "" {
+				return "", "", validationError("Too many range keys specified")
+			}
+			rangeKey = name
+		default:
+			return "", "", validationError(fmt.Sprintf("Invalid KeyType %s", key.KeyType))
+		}
+	}
+
+	if hashKey == "" {
+		return "", "", validationError("No hash key specified in the key schema")
+	}
+
+	return hashKey, rangeKey, nil
+}
+
+func validationError(msg string) error {
+	return &smithy.GenericAPIError{
+		Code:    "ValidationException",
+		Message: msg,
+		Fault:   smithy.FaultClient,
+	}
+}
@@ -0,0 +1,542 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+func newTestTable(t *testing.T, db *DB, tableName string, skType types.ScalarAttributeType) {
+	_, err := db.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName: aws.String(tableName),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("PK"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("SK"), KeyType: types.KeyTypeRange},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("PK"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("SK"), AttributeType: skType},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	assert.NoError(t, err)
+}
+
+func putTestItem(t *testing.T, db *DB, tableName string, pk string, sk types.AttributeValue) {
+	_, err := db.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": sk,
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func querySK(t *testing.T, output *dynamodb.QueryOutput) []string {
+	var result []string
+	for _, item := range output.Items {
+		switch sk := item["SK"].(type) {
+		case *types.AttributeValueMemberS:
+			result = append(result, sk.Value)
+		case *types.AttributeValueMemberN:
+			result = append(result, sk.Value)
+		}
+	}
+	return result
+}
+
+func Test_MemDBTable(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	t.Run("1. 테이블 생성 / 중복 생성 에러", func(t *testing.T) {
+		newTestTable(t, db, "logs", types.ScalarAttributeTypeS)
+
+		_, err := db.CreateTable(ctx, &dynamodb.CreateTableInput{
+			TableName: aws.String("logs"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("PK"), KeyType: types.KeyTypeHash},
+			},
+			AttributeDefinitions: []types.AttributeDefinition{
+				{AttributeName: aws.String("PK"), AttributeType: types.ScalarAttributeTypeS},
+			},
+			BillingMode: types.BillingModePayPerRequest,
+		})
+
+		var inUse *types.ResourceInUseException
+		assert.True(t, errors.As(err, &inUse))
+	})
+
+	t.Run("2. 테이블 목록 / 상세 조회", func(t *testing.T) {
+		tables, err := db.ListTables(ctx, &dynamodb.ListTablesInput{})
+		assert.NoError(t, err)
+		assert.Eq(t, tables.TableNames, []string{"logs"})
+
+		output, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("logs")})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Table.TableStatus, types.TableStatusActive)
+		assert.Eq(t, *output.Table.ItemCount, int64(0))
+	})
+
+	t.Run("3. 없는 테이블 조회 에러", func(t *testing.T) {
+		_, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("unknown")})
+
+		var notFound *types.ResourceNotFoundException
+		assert.True(t, errors.As(err, &notFound))
+	})
+
+	t.Run("4. TTL 설정", func(t *testing.T) {
+		ttl := func(name string, enabled bool) error {
+			_, err := db.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+				TableName: aws.String("logs"),
+				TimeToLiveSpecification: &types.TimeToLiveSpecification{
+					AttributeName: aws.String(name),
+					Enabled:       aws.Bool(enabled),
+				},
+			})
+			return err
+		}
+
+		assert.Err(t, ttl("ExpiresAt", false))
+		assert.NoError(t, ttl("ExpiresAt", true))
+		assert.Err(t, ttl("ExpiresAt", true))
+
+		output, err := db.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("logs")})
+		assert.NoError(t, err)
+		assert.Eq(t, output.TimeToLiveDescription.TimeToLiveStatus, types.TimeToLiveStatusEnabled)
+		assert.Eq(t, aws.ToString(output.TimeToLiveDescription.AttributeName), "ExpiresAt")
+
+		assert.Err(t, ttl("Other", false))
+		assert.NoError(t, ttl("ExpiresAt", false))
+	})
+
+	t.Run("5. UpdateTable (billing mode / GSI 추가)", func(t *testing.T) {
+		_, err := db.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:   aws.String("logs"),
+			BillingMode: types.BillingModeProvisioned,
+		})
+		assert.Err(t, err)
+
+		_, err = db.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:   aws.String("logs"),
+			BillingMode: types.BillingModeProvisioned,
+			ProvisionedThroughput: &types.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(5),
+				WriteCapacityUnits: aws.Int64(5),
+			},
+		})
+		assert.NoError(t, err)
+
+		gsi := func(name string) types.GlobalSecondaryIndexUpdate {
+			return types.GlobalSecondaryIndexUpdate{
+				Create: &types.CreateGlobalSecondaryIndexAction{
+					IndexName: aws.String(name),
+					KeySchema: []types.KeySchemaElement{
+						{AttributeName: aws.String("Status"), KeyType: types.KeyTypeHash},
+					},
+					Projection:            &types.Projection{ProjectionType: types.ProjectionTypeAll},
+					ProvisionedThroughput: &types.ProvisionedThroughput{ReadCapacityUnits: aws.Int64(1), WriteCapacityUnits: aws.Int64(1)},
+				},
+			}
+		}
+		definitions := []types.AttributeDefinition{
+			{AttributeName: aws.String("Status"), AttributeType: types.ScalarAttributeTypeS},
+		}
+
+		// GSI 는 한번에 하나만 추가
+		_, err = db.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:                   aws.String("logs"),
+			AttributeDefinitions:        definitions,
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{gsi("GSI1"), gsi("GSI2")},
+		})
+		assert.Err(t, err)
+
+		output, err := db.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:                   aws.String("logs"),
+			AttributeDefinitions:        definitions,
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{gsi("GSI1")},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, output.TableDescription.BillingModeSummary.BillingMode, types.BillingModeProvisioned)
+		assert.Eq(t, aws.ToInt64(output.TableDescription.ProvisionedThroughput.ReadCapacityUnits), int64(5))
+		assert.Len(t, output.TableDescription.GlobalSecondaryIndexes, 1)
+		assert.Eq(t, aws.ToString(output.TableDescription.GlobalSecondaryIndexes[0].IndexName), "GSI1")
+	})
+
+	t.Run("6. 테이블 삭제", func(t *testing.T) {
+		_, err := db.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String("logs")})
+		assert.NoError(t, err)
+
+		tables, err := db.ListTables(ctx, &dynamodb.ListTablesInput{})
+		assert.NoError(t, err)
+		assert.Len(t, tables.TableNames, 0)
+	})
+}
+
+func Test_MemDBItem(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	newTestTable(t, db, "users", types.ScalarAttributeTypeS)
+
+	t.Run("1. attribute_not_exists 조건부 추가", func(t *testing.T) {
+		input := &dynamodb.PutItemInput{
+			TableName: aws.String("users"),
+			Item: map[string]types.AttributeValue{
+				"PK":   &types.AttributeValueMemberS{Value: "USER#1"},
+				"SK":   &types.AttributeValueMemberS{Value: "#PROFILE"},
+				"Name": &types.AttributeValueMemberS{Value: "tom"},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(PK)"),
+		}
+
+		_, err := db.PutItem(ctx, input)
+		assert.NoError(t, err)
+
+		_, err = db.PutItem(ctx, input)
+
+		var condFailed *types.ConditionalCheckFailedException
+		assert.True(t, errors.As(err, &condFailed))
+	})
+
+	t.Run("2. 단건 조회 / key 누락 에러", func(t *testing.T) {
+		output, err := db.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String("users"),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: "USER#1"},
+				"SK": &types.AttributeValueMemberS{Value: "#PROFILE"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Item["Name"], &types.AttributeValueMemberS{Value: "tom"})
+
+		_, err = db.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String("users"),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: "USER#1"},
+			},
+		})
+		assert.Err(t, err)
+	})
+
+	t.Run("3. batch write / delete", func(t *testing.T) {
+		_, err := db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				"users": {
+					{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: "USER#2"},
+						"SK": &types.AttributeValueMemberS{Value: "#PROFILE"},
+					}}},
+					{DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: "USER#1"},
+						"SK": &types.AttributeValueMemberS{Value: "#PROFILE"},
+					}}},
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		output, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("users")})
+		assert.NoError(t, err)
+		assert.Eq(t, *output.Table.ItemCount, int64(1))
+	})
+
+	t.Run("4. batch get / 중복 key 에러", func(t *testing.T) {
+		key := func(pk string) map[string]types.AttributeValue {
+			return map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: pk},
+				"SK": &types.AttributeValueMemberS{Value: "#PROFILE"},
+			}
+		}
+
+		output, err := db.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{
+				"users": {Keys: []map[string]types.AttributeValue{key("USER#1"), key("USER#2")}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, output.Responses["users"], 1)
+		assert.Len(t, output.UnprocessedKeys, 0)
+
+		_, err = db.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{
+				"users": {Keys: []map[string]types.AttributeValue{key("USER#2"), key("USER#2")}},
+			},
+		})
+		assert.Err(t, err)
+	})
+
+	t.Run("5. update item (ReturnValues / 겹치는 path 에러)", func(t *testing.T) {
+		key := map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "USER#2"},
+			"SK": &types.AttributeValueMemberS{Value: "#PROFILE"},
+		}
+
+		output, err := db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:        aws.String("users"),
+			Key:              key,
+			UpdateExpression: aws.String("SET Age = if_not_exists(Age, :zero) + :n, Name = :name"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":zero": &types.AttributeValueMemberN{Value: "0"},
+				":n":    &types.AttributeValueMemberN{Value: "3"},
+				":name": &types.AttributeValueMemberS{Value: "tom"},
+			},
+			ReturnValues: types.ReturnValueUpdatedNew,
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Attributes, map[string]types.AttributeValue{
+			"Age":  &types.AttributeValueMemberN{Value: "3"},
+			"Name": &types.AttributeValueMemberS{Value: "tom"},
+		})
+
+		output, err = db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:        aws.String("users"),
+			Key:              key,
+			UpdateExpression: aws.String("REMOVE Name"),
+			ReturnValues:     types.ReturnValueUpdatedOld,
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Attributes, map[string]types.AttributeValue{
+			"Name": &types.AttributeValueMemberS{Value: "tom"},
+		})
+
+		_, err = db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:        aws.String("users"),
+			Key:              key,
+			UpdateExpression: aws.String("SET Info.City = :city REMOVE Info"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":city": &types.AttributeValueMemberS{Value: "Seoul"},
+			},
+		})
+		assert.Err(t, err)
+	})
+
+	t.Run("6. 구분자가 들어간 key 는 다른 item", func(t *testing.T) {
+		newTestTable(t, db, "keys", types.ScalarAttributeTypeS)
+
+		putTestItem(t, db, "keys", "a|S:b", &types.AttributeValueMemberS{Value: "c"})
+		putTestItem(t, db, "keys", "a", &types.AttributeValueMemberS{Value: "b|S:c"})
+
+		output, err := db.Scan(ctx, &dynamodb.ScanInput{TableName: aws.String("keys")})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Count, int32(2))
+	})
+}
+
+func Test_MemDBQuery(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	newTestTable(t, db, "orders", types.ScalarAttributeTypeS)
+	newTestTable(t, db, "events", types.ScalarAttributeTypeN)
+
+	for _, sk := range []string{"#PROFILE", "ORDER#001", "ORDER#002", "ORDER#003", "TEAM#DEV"} {
+		putTestItem(t, db, "orders", "USER#1", &types.AttributeValueMemberS{Value: sk})
+	}
+	putTestItem(t, db, "orders", "USER#2", &types.AttributeValueMemberS{Value: "ORDER#004"})
+
+	for _, sk := range []string{"9", "10", "100", "2"} {
+		putTestItem(t, db, "events", "ROOM#1", &types.AttributeValueMemberN{Value: sk})
+	}
+
+	query := func(t *testing.T, tableName, expression string, values map[string]types.AttributeValue, limit int32, forward bool) *dynamodb.QueryOutput {
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String(tableName),
+			KeyConditionExpression:    aws.String(expression),
+			ExpressionAttributeValues: values,
+			ScanIndexForward:          aws.Bool(forward),
+		}
+		if limit > 0 {
+			input.Limit = aws.Int32(limit)
+		}
+
+		output, err := db.Query(ctx, input)
+		assert.NoError(t, err)
+		return output
+	}
+
+	t.Run("1. PK = 조회", func(t *testing.T) {
+		output := query(t, "orders", "PK = :pk", map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+		}, 0, true)
+
+		assert.Eq(t, querySK(t, output), []string{"#PROFILE", "ORDER#001", "ORDER#002", "ORDER#003", "TEAM#DEV"})
+	})
+
+	t.Run("2. begins_with 조회", func(t *testing.T) {
+		output := query(t, "orders", "PK = :pk AND begins_with(SK, :sk)", map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+			":sk": &types.AttributeValueMemberS{Value: "ORDER#"},
+		}, 0, true)
+
+		assert.Eq(t, querySK(t, output), []string{"ORDER#001", "ORDER#002", "ORDER#003"})
+	})
+
+	t.Run("3. BETWEEN 조회", func(t *testing.T) {
+		output := query(t, "orders", "PK = :pk AND SK BETWEEN :from AND :to", map[string]types.AttributeValue{
+			":pk":   &types.AttributeValueMemberS{Value: "USER#1"},
+			":from": &types.AttributeValueMemberS{Value: "ORDER#002"},
+			":to":   &types.AttributeValueMemberS{Value: "ORDER#003"},
+		}, 0, true)
+
+		assert.Eq(t, querySK(t, output), []string{"ORDER#002", "ORDER#003"})
+	})
+
+	t.Run("4. Number SK < 조회 (숫자 정렬)", func(t *testing.T) {
+		output := query(t, "events", "PK = :pk AND SK < :sk", map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: "ROOM#1"},
+			":sk": &types.AttributeValueMemberN{Value: "50"},
+		}, 0, true)
+
+		assert.Eq(t, querySK(t, output), []string{"2", "9", "10"})
+	})
+
+	t.Run("5. Limit + ScanIndexForward + LastEvaluatedKey", func(t *testing.T) {
+		values := map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+		}
+
+		output := query(t, "orders", "PK = :pk", values, 2, false)
+		assert.Eq(t, querySK(t, output), []string{"TEAM#DEV", "ORDER#003"})
+		assert.NotNil(t, output.LastEvaluatedKey)
+
+		next, err := db.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String("orders"),
+			KeyConditionExpression:    aws.String("PK = :pk"),
+			ExpressionAttributeValues: values,
+			ScanIndexForward:          aws.Bool(false),
+			ExclusiveStartKey:         output.LastEvaluatedKey,
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, querySK(t, next), []string{"ORDER#002", "ORDER#001", "#PROFILE"})
+		assert.Nil(t, next.LastEvaluatedKey)
+	})
+
+	t.Run("6. 잘못된 KeyConditionExpression 에러", func(t *testing.T) {
+		_, err := db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String("orders"),
+			KeyConditionExpression: aws.String("begins_with(SK, :sk)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":sk": &types.AttributeValueMemberS{Value: "ORDER#"},
+			},
+		})
+		assert.Err(t, err)
+
+		_, err = db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String("orders"),
+			KeyConditionExpression: aws.String("PK = :pk OR SK = :pk"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+			},
+		})
+		assert.Err(t, err)
+	})
+}
+
+func Test_MemDBScan(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	newTestTable(t, db, "orders", types.ScalarAttributeTypeS)
+
+	for i := 1; i <= 10; i++ {
+		_, err := db.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String("orders"),
+			Item: map[string]types.AttributeValue{
+				"PK":     &types.AttributeValueMemberS{Value: fmt.Sprintf("USER#%d", i)},
+				"SK":     &types.AttributeValueMemberS{Value: "ORDER#001"},
+				"Amount": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", i*100)},
+				"Detail": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"Memo": &types.AttributeValueMemberS{Value: "memo"},
+					"Tag":  &types.AttributeValueMemberS{Value: "tag"},
+				}},
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("1. Limit + ExclusiveStartKey 로 전체 scan", func(t *testing.T) {
+		seen := map[string]struct{}{}
+		var startKey map[string]types.AttributeValue
+		for {
+			output, err := db.Scan(ctx, &dynamodb.ScanInput{
+				TableName:         aws.String("orders"),
+				Limit:             aws.Int32(3),
+				ExclusiveStartKey: startKey,
+			})
+			assert.NoError(t, err)
+
+			for _, item := range output.Items {
+				seen[item["PK"].(*types.AttributeValueMemberS).Value] = struct{}{}
+			}
+
+			if output.LastEvaluatedKey == nil {
+				break
+			}
+			startKey = output.LastEvaluatedKey
+		}
+
+		assert.Len(t, seen, 10)
+	})
+
+	t.Run("2. FilterExpression 은 Limit 적용 후 거름 (ScannedCount / Count)", func(t *testing.T) {
+		output, err := db.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String("orders"),
+			FilterExpression: aws.String("Amount > :amount"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":amount": &types.AttributeValueMemberN{Value: "500"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, output.Count, int32(5))
+		assert.Eq(t, output.ScannedCount, int32(10))
+	})
+
+	t.Run("3. ProjectionExpression (nested path)", func(t *testing.T) {
+		output, err := db.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                aws.String("orders"),
+			Limit:                    aws.Int32(1),
+			ProjectionExpression:     aws.String("PK, #d.Memo"),
+			ExpressionAttributeNames: map[string]string{"#d": "Detail"},
+		})
+		assert.NoError(t, err)
+
+		item := output.Items[0]
+		assert.Len(t, item, 2)
+		detail := item["Detail"].(*types.AttributeValueMemberM).Value
+		assert.Len(t, detail, 1)
+		assert.ContainsKey(t, detail, "Memo")
+	})
+
+	t.Run("4. segment 별로 겹치지 않게 나눠짐", func(t *testing.T) {
+		seen := map[string]int{}
+		for segment := range 3 {
+			output, err := db.Scan(ctx, &dynamodb.ScanInput{
+				TableName:     aws.String("orders"),
+				Segment:       aws.Int32(int32(segment)),
+				TotalSegments: aws.Int32(3),
+			})
+			assert.NoError(t, err)
+
+			for _, item := range output.Items {
+				seen[item["PK"].(*types.AttributeValueMemberS).Value]++
+			}
+		}
+
+		assert.Len(t, seen, 10)
+		for _, count := range seen {
+			assert.Eq(t, count, 1)
+		}
+
+		_, err := db.Scan(ctx, &dynamodb.ScanInput{
+			TableName:     aws.String("orders"),
+			Segment:       aws.Int32(3),
+			TotalSegments: aws.Int32(3),
+		})
+		assert.Err(t, err)
+	})
+}
@@ -0,0 +1,275 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Query 대상 key 정보 (table 또는 index)
+type keySpec struct {
+	hashKey  string
+	rangeKey string
+}
+
+func (db *DB) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	spec, idx, err := t.keySpec(params.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.ToString(params.KeyConditionExpression) == "" {
+		return nil, validationError("Either the KeyConditions or KeyConditionExpression parameter must be specified in the request")
+	}
+
+	cond, err := parseCondition(*params.KeyConditionExpression, params.ExpressionAttributeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKeyCondition(cond, spec); err != nil {
+		return nil, err
+	}
+
+	var filter node
+	if aws.ToString(params.FilterExpression) != "" {
+		if filter, err = parseCondition(*params.FilterExpression, params.ExpressionAttributeNames); err != nil {
+			return nil, err
+		}
+	}
+
+	var projection []pathNode
+	if aws.ToString(params.ProjectionExpression) != "" {
+		if projection, err = parseProjection(*params.ProjectionExpression, params.ExpressionAttributeNames); err != nil {
+			return nil, err
+		}
+	}
+
+	var matched []map[string]types.AttributeValue
+	for _, item := range t.items {
+		if !spec.contains(item) {
+			continue
+		}
+
+		ok, err := evaluateCondition(cond, item, params.ExpressionAttributeValues)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+
+	forward := params.ScanIndexForward == nil || *params.ScanIndexForward
+	less := func(a, b map[string]types.AttributeValue) bool {
+		if forward {
+			return t.less(spec, a, b)
+		}
+		return t.less(spec, b, a)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return less(matched[i], matched[j])
+	})
+
+	start := 0
+	if params.ExclusiveStartKey != nil {
+		start = sort.Search(len(matched), func(i int) bool {
+			return less(params.ExclusiveStartKey, matched[i])
+		})
+	}
+	matched = matched[start:]
+
+	output := &dynamodb.QueryOutput{}
+
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			return nil, validationError("Limit must be greater than or equal to 1")
+		}
+		if int(*params.Limit) < len(matched) {
+			matched = matched[:*params.Limit]
+			output.LastEvaluatedKey = t.evaluatedKey(spec, matched[len(matched)-1])
+		}
+	}
+
+	// Limit 은 filter 적용 전 item 수 기준
+	output.Items = make([]map[string]types.AttributeValue, 0, len(matched))
+	for _, item := range matched {
+		if filter != nil {
+			ok, err := evaluateCondition(filter, item, params.ExpressionAttributeValues)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		if idx != nil {
+			item = t.project(idx, item)
+		}
+		if projection != nil {
+			item = projectPaths(item, projection)
+		}
+
+		output.Items = append(output.Items, copyItem(item))
+	}
+	output.Count = int32(len(output.Items))
+	output.ScannedCount = int32(len(matched))
+
+	return output, nil
+}
+
+// indexName 이 nil 이면 table key, 아니면 index key
+func (t *table) keySpec(indexName *string) (keySpec, *index, error) {
+	if indexName == nil {
+		return keySpec{hashKey: t.hashKey, rangeKey: t.rangeKey}, nil, nil
+	}
+
+	idx, ok := t.indexes[*indexName]
+	if !ok {
+		return keySpec{}, nil, validationError(fmt.Sprintf("The table does not have the specified index: %s", *indexName))
+	}
+
+	return idx.spec, idx, nil
+}
+
+// index key 가 없는 item 은 index 에 포함되지 않음 (sparse index)
+func (spec keySpec) contains(item map[string]types.AttributeValue) bool {
+	for _, name := range []string{spec.hashKey, spec.rangeKey} {
+		if _, ok := item[name]; name != "" && !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// index projection 에 포함된 attribute 만 반환
+func (t *table) project(idx *index, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if idx.projection.ProjectionType == types.ProjectionTypeAll {
+		return item
+	}
+
+	projected := t.evaluatedKey(idx.spec, item)
+	if idx.projection.ProjectionType == types.ProjectionTypeInclude {
+		for _, name := range idx.projection.NonKeyAttributes {
+			if value, ok := item[name]; ok {
+				projected[name] = value
+			}
+		}
+	}
+
+	return projected
+}
+
+// KeyConditionExpression 은 hash key 의 = 조건 하나와 range key 조건 최대 하나만 허용
+func validateKeyCondition(n node, spec keySpec) error {
+	var conditions []node
+
+	var flatten func(n node) error
+	flatten = func(n node) error {
+		if logic, ok := n.(logicNode); ok {
+			if logic.op != "AND" {
+				return validationError("Invalid operator used in KeyConditionExpression: OR")
+			}
+			if err := flatten(logic.left); err != nil {
+				return err
+			}
+			return flatten(logic.right)
+		}
+		conditions = append(conditions, n)
+		return nil
+	}
+
+	if err := flatten(n); err != nil {
+		return err
+	}
+
+	hasHash, hasRange := false, false
+	for _, c := range conditions {
+		name, op := keyConditionTarget(c)
+
+		switch {
+		case name == spec.hashKey && op == "=" && !hasHash:
+			hasHash = true
+		case name != "" && name == spec.rangeKey && op != "<>" && !hasRange:
+			hasRange = true
+		case name == "":
+			return validationError("Invalid KeyConditionExpression")
+		default:
+			return validationError(fmt.Sprintf("Query key condition not supported on attribute %s", name))
+		}
+	}
+
+	if !hasHash {
+		return validationError(fmt.Sprintf("Query condition missed key schema element: %s", spec.hashKey))
+	}
+
+	return nil
+}
+
+func keyConditionTarget(n node) (string, string) {
+	topLevel := func(n node) string {
+		path, ok := n.(pathNode)
+		if !ok || len(path.parts) != 1 || path.parts[0].isIndex {
+			return ""
+		}
+		return path.parts[0].name
+	}
+
+	switch v := n.(type) {
+	case compareNode:
+		return topLevel(v.left), v.op
+	case betweenNode:
+		return topLevel(v.target), "BETWEEN"
+	case funcNode:
+		if v.name == "begins_with" && len(v.args) == 2 {
+			return topLevel(v.args[0]), "begins_with"
+		}
+	}
+
+	return "", ""
+}
+
+// spec 의 range key 기준 정렬, 같으면 table primary key 로 정렬
+func (t *table) less(spec keySpec, a, b map[string]types.AttributeValue) bool {
+	if spec.rangeKey != "" {
+		if cmp, ok := compareValues(a[spec.rangeKey], b[spec.rangeKey]); ok && cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	for _, name := range t.keyNames() {
+		if cmp, ok := compareValues(a[name], b[name]); ok && cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	return false
+}
+
+// LastEvaluatedKey = table primary key + 조회한 index key
+func (t *table) evaluatedKey(spec keySpec, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	key := t.primaryKey(item)
+
+	for _, name := range []string{spec.hashKey, spec.rangeKey} {
+		if name != "" {
+			key[name] = copyValue(item[name])
+		}
+	}
+
+	return key
+}
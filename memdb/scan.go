@@ -0,0 +1,181 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	maxTotalSegments = 1000000
+)
+
+func (db *DB) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	t, err := db.table(aws.ToString(params.TableName))
+	if err != nil {
+		return nil, err
+	}
+
+	spec, idx, err := t.keySpec(params.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	segment, total, err := scanSegment(params.Segment, params.TotalSegments)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter node
+	if aws.ToString(params.FilterExpression) != "" {
+		if filter, err = parseCondition(*params.FilterExpression, params.ExpressionAttributeNames); err != nil {
+			return nil, err
+		}
+	}
+
+	var projection []pathNode
+	if aws.ToString(params.ProjectionExpression) != "" {
+		if projection, err = parseProjection(*params.ProjectionExpression, params.ExpressionAttributeNames); err != nil {
+			return nil, err
+		}
+	}
+
+	var scanned []map[string]types.AttributeValue
+	for _, item := range t.items {
+		if spec.contains(item) && segmentOf(item[spec.hashKey], total) == segment {
+			scanned = append(scanned, item)
+		}
+	}
+
+	// hash key -> range key -> table primary key 순으로 고정 (페이지 사이 순서 유지)
+	less := func(a, b map[string]types.AttributeValue) bool {
+		if cmp, ok := compareValues(a[spec.hashKey], b[spec.hashKey]); ok && cmp != 0 {
+			return cmp < 0
+		}
+		return t.less(spec, a, b)
+	}
+
+	sort.Slice(scanned, func(i, j int) bool {
+		return less(scanned[i], scanned[j])
+	})
+
+	if params.ExclusiveStartKey != nil {
+		start := sort.Search(len(scanned), func(i int) bool {
+			return less(params.ExclusiveStartKey, scanned[i])
+		})
+		scanned = scanned[start:]
+	}
+
+	output := &dynamodb.ScanOutput{}
+
+	// Limit 은 filter 적용 전 item 수 기준
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			return nil, validationError("Limit must be greater than or equal to 1")
+		}
+		if int(*params.Limit) < len(scanned) {
+			scanned = scanned[:*params.Limit]
+			output.LastEvaluatedKey = t.evaluatedKey(spec, scanned[len(scanned)-1])
+		}
+	}
+
+	output.Items = make([]map[string]types.AttributeValue, 0, len(scanned))
+	for _, item := range scanned {
+		if filter != nil {
+			ok, err := evaluateCondition(filter, item, params.ExpressionAttributeValues)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		if idx != nil {
+			item = t.project(idx, item)
+		}
+		if projection != nil {
+			item = projectPaths(item, projection)
+		}
+
+		output.Items = append(output.Items, copyItem(item))
+	}
+	output.Count = int32(len(output.Items))
+	output.ScannedCount = int32(len(scanned))
+
+	return output, nil
+}
+
+// Segment 와 TotalSegments 는 같이 지정해야 함 (둘 다 없으면 segment 0 / 1)
+func scanSegment(segment, total *int32) (uint32, uint32, error) {
+	if segment == nil && total == nil {
+		return 0, 1, nil
+	}
+
+	if segment == nil || total == nil {
+		return 0, 0, validationError("The Segment parameter is required but was not present in the request when parameter TotalSegments is present")
+	}
+
+	if *total < 1 || *total > maxTotalSegments {
+		return 0, 0, validationError(fmt.Sprintf("1 validation error detected: Value '%d' at 'totalSegments' failed to satisfy constraint: Member must have value between 1 and %d", *total, maxTotalSegments))
+	}
+
+	if *segment < 0 || *segment >= *total {
+		return 0, 0, validationError(fmt.Sprintf("The Segment parameter is zero-based and must be less than parameter TotalSegments: Segment: %d is not less than TotalSegments: %d", *segment, *total))
+	}
+
+	return uint32(*segment), uint32(*total), nil
+}
+
+// 같은 partition key 는 항상 같은 segment
+func segmentOf(hashKey types.AttributeValue, total uint32) uint32 {
+	if total <= 1 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(keyString(hashKey)))
+
+	return h.Sum32() % total
+}
+
+// ProjectionExpression 에 포함된 attribute 만 반환 (list index 는 list 전체를 반환)
+func projectPaths(item map[string]types.AttributeValue, paths []pathNode) map[string]types.AttributeValue {
+	projected := map[string]types.AttributeValue{}
+
+	for _, path := range paths {
+		parts := path.parts
+		for i, part := range parts {
+			if part.isIndex {
+				parts = parts[:i]
+				break
+			}
+		}
+
+		value, ok := resolvePath(item, parts)
+		if !ok {
+			continue
+		}
+
+		dst := projected
+		for _, part := range parts[:len(parts)-1] {
+			m, ok := dst[part.name].(*types.AttributeValueMemberM)
+			if !ok {
+				m = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}}
+				dst[part.name] = m
+			}
+			dst = m.Value
+		}
+		dst[parts[len(parts)-1].name] = copyValue(value)
+	}
+
+	return projected
+}
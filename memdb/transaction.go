@@ -0,0 +1,214 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	maxTransactItems = 100
+)
+
+func (db *DB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(params.TransactItems) == 0 || len(params.TransactItems) > maxTransactItems {
+		return nil, validationError(fmt.Sprintf("Member must have length less than or equal to %d: %d", maxTransactItems, len(params.TransactItems)))
+	}
+
+	// 1. 검증 + 결과 계산 (아직 반영하지 않음)
+	type write struct {
+		t    *table
+		key  string
+		item map[string]types.AttributeValue // nil 이면 삭제, ConditionCheck 는 write 없음
+		skip bool
+	}
+
+	writes := make([]write, len(params.TransactItems))
+	reasons := make([]types.CancellationReason, len(params.TransactItems))
+	seen := map[string]struct{}{}
+	canceled := false
+
+	for i, transactItem := range params.TransactItems {
+		var (
+			tableName  *string
+			key        map[string]types.AttributeValue
+			condition  *string
+			names      map[string]string
+			values     map[string]types.AttributeValue
+			onFailure  types.ReturnValuesOnConditionCheckFailure
+			apply      func(t *table, old map[string]types.AttributeValue) (map[string]types.AttributeValue, error)
+			checkOnly  bool
+			deleteItem bool
+		)
+
+		switch {
+		case transactItem.Put != nil:
+			op := transactItem.Put
+			tableName, condition, names, values, onFailure = op.TableName, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues, op.ReturnValuesOnConditionCheckFailure
+			key = op.Item
+			apply = func(t *table, old map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+				return copyItem(op.Item), t.validateIndexKeys(op.Item)
+			}
+
+		case transactItem.Update != nil:
+			op := transactItem.Update
+			tableName, condition, names, values, onFailure = op.TableName, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues, op.ReturnValuesOnConditionCheckFailure
+			key = op.Key
+			apply = func(t *table, old map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+				return t.update(old, op.Key, aws.ToString(op.UpdateExpression), op.ExpressionAttributeNames, op.ExpressionAttributeValues)
+			}
+
+		case transactItem.Delete != nil:
+			op := transactItem.Delete
+			tableName, condition, names, values, onFailure = op.TableName, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues, op.ReturnValuesOnConditionCheckFailure
+			key = op.Key
+			deleteItem = true
+
+		case transactItem.ConditionCheck != nil:
+			op := transactItem.ConditionCheck
+			tableName, condition, names, values, onFailure = op.TableName, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues, op.ReturnValuesOnConditionCheckFailure
+			key = op.Key
+			checkOnly = true
+			if aws.ToString(condition) == "" {
+				return nil, validationError("ConditionCheck requires a ConditionExpression")
+			}
+
+		default:
+			return nil, validationError("TransactItem must contain one of Put, Update, Delete or ConditionCheck")
+		}
+
+		t, err := db.table(aws.ToString(tableName))
+		if err != nil {
+			return nil, err
+		}
+
+		var storeKey string
+		if transactItem.Put != nil {
+			storeKey, err = t.itemKey(key)
+		} else {
+			storeKey, err = t.lookupKey(key)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[t.name+"/"+storeKey]; ok {
+			return nil, validationError("Transaction request cannot include multiple operations on one item")
+		}
+		seen[t.name+"/"+storeKey] = struct{}{}
+
+		old := t.items[storeKey]
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+
+		if err := checkCondition(condition, names, values, old, onFailure); err != nil {
+			var condFailed *types.ConditionalCheckFailedException
+			if !errors.As(err, &condFailed) {
+				return nil, err
+			}
+
+			canceled = true
+			reasons[i] = types.CancellationReason{
+				Code:    aws.String("ConditionalCheckFailed"),
+				Message: condFailed.Message,
+				Item:    condFailed.Item,
+			}
+			continue
+		}
+
+		w := write{t: t, key: storeKey, skip: checkOnly}
+		if apply != nil && !deleteItem {
+			w.item, err = apply(t, old)
+			if err != nil {
+				return nil, err
+			}
+		}
+		writes[i] = w
+	}
+
+	if canceled {
+		codes := make([]string, len(reasons))
+		for i, reason := range reasons {
+			codes[i] = aws.ToString(reason.Code)
+		}
+
+		return nil, &types.TransactionCanceledException{
+			Message:             aws.String(fmt.Sprintf("Transaction cancelled, please refer cancellation reasons for specific reasons [%s]", strings.Join(codes, ", "))),
+			CancellationReasons: reasons,
+		}
+	}
+
+	// 2. 한번에 반영
+	for _, w := range writes {
+		if w.skip {
+			continue
+		}
+		if w.item == nil {
+			delete(w.t.items, w.key)
+			continue
+		}
+		w.t.items[w.key] = w.item
+	}
+
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+// 기존 item (없으면 key 로 새 item) 에 UpdateExpression 적용
+func (t *table) update(old, key map[string]types.AttributeValue, expression string, names map[string]string, values map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	actions, err := parseUpdate(expression, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if old == nil {
+		old = copyItem(key)
+	}
+
+	item, err := applyUpdate(old, actions, values, t.keyNames())
+	if err != nil {
+		return nil, err
+	}
+
+	return item, t.validateIndexKeys(item)
+}
+
+func (db *DB) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if len(params.TransactItems) == 0 || len(params.TransactItems) > maxTransactItems {
+		return nil, validationError(fmt.Sprintf("Member must have length less than or equal to %d: %d", maxTransactItems, len(params.TransactItems)))
+	}
+
+	responses := make([]types.ItemResponse, len(params.TransactItems))
+	for i, transactItem := range params.TransactItems {
+		if transactItem.Get == nil {
+			return nil, validationError("TransactItem must contain Get")
+		}
+
+		t, err := db.table(aws.ToString(transactItem.Get.TableName))
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := t.lookupKey(transactItem.Get.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		responses[i] = types.ItemResponse{
+			Item: copyItem(t.items[key]),
+		}
+	}
+
+	return &dynamodb.TransactGetItemsOutput{
+		Responses: responses,
+	}, nil
+}
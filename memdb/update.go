@@ -0,0 +1,461 @@
+package memdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// UpdateExpression (SET / REMOVE / ADD / DELETE) 파서
+
+type updateAction struct {
+	clause string // SET / REMOVE / ADD / DELETE
+	path   pathNode
+	value  node // SET: operand 또는 arithmeticNode, ADD / DELETE: valueNode
+}
+
+type arithmeticNode struct {
+	op          string // + / -
+	left, right node
+}
+
+func parseUpdate(expression string, names map[string]string) ([]updateAction, error) {
+	p, err := newParser(expression, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []updateAction
+	seen := map[string]struct{}{}
+
+	for p.peek().kind != tokenEOF {
+		t := p.next()
+		clause := strings.ToUpper(t.text)
+		if t.kind != tokenIdent {
+			return nil, p.errorf("expected SET, REMOVE, ADD or DELETE but got %q", t.text)
+		}
+		if _, ok := seen[clause]; ok {
+			return nil, p.errorf("the %s section can only be used once in an update expression", clause)
+		}
+		seen[clause] = struct{}{}
+
+		for {
+			action, err := p.parseUpdateAction(clause)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, action)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if len(actions) == 0 {
+		return nil, validationError("Invalid UpdateExpression: The expression can not be empty")
+	}
+
+	for i := range actions {
+		for j := i + 1; j < len(actions); j++ {
+			if overlaps(actions[i].path, actions[j].path) {
+				return nil, validationError(fmt.Sprintf("Invalid UpdateExpression: Two document paths overlap with each other; must remove or rewrite one of these paths; path one: %s, path two: %s", actions[i].path, actions[j].path))
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// 한쪽 path 가 다른 path 의 앞부분이면 겹침
+func overlaps(a, b pathNode) bool {
+	for i := 0; i < len(a.parts) && i < len(b.parts); i++ {
+		if a.parts[i] != b.parts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p pathNode) String() string {
+	var parts []string
+	for _, part := range p.parts {
+		if part.isIndex {
+			parts = append(parts, fmt.Sprintf("[%d]", part.index))
+			continue
+		}
+		parts = append(parts, part.name)
+	}
+
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func (p *parser) parseUpdateAction(clause string) (updateAction, error) {
+	target, err := p.parsePath()
+	if err != nil {
+		return updateAction{}, err
+	}
+	path := target.(pathNode)
+
+	switch clause {
+	case "SET":
+		if t := p.next(); t.kind != tokenOperator || t.text != "=" {
+			return updateAction{}, p.errorf("expected = but got %q", t.text)
+		}
+		value, err := p.parseSetValue()
+		if err != nil {
+			return updateAction{}, err
+		}
+		return updateAction{clause: clause, path: path, value: value}, nil
+
+	case "REMOVE":
+		return updateAction{clause: clause, path: path}, nil
+
+	case "ADD", "DELETE":
+		t := p.next()
+		if t.kind != tokenValue {
+			return updateAction{}, p.errorf("%s requires an expression attribute value but got %q", clause, t.text)
+		}
+		return updateAction{clause: clause, path: path, value: valueNode{name: t.text}}, nil
+	}
+
+	return updateAction{}, p.errorf("invalid update clause %q", clause)
+}
+
+func (p *parser) parseSetValue() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == tokenOperator && (t.text == "+" || t.text == "-") {
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return arithmeticNode{op: t.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+// item 복사본에 update 를 적용해서 반환
+func applyUpdate(item map[string]types.AttributeValue, actions []updateAction, values map[string]types.AttributeValue, keyNames []string) (map[string]types.AttributeValue, error) {
+	// 모든 값은 update 전 item 기준으로 계산
+	e := evaluator{item: item, values: values}
+	result := copyItem(item)
+
+	for _, action := range actions {
+		if len(action.path.parts) == 1 {
+			for _, name := range keyNames {
+				if action.path.parts[0].name == name {
+					return nil, validationError(fmt.Sprintf("Cannot update attribute %s. This attribute is part of the key", name))
+				}
+			}
+		}
+
+		switch action.clause {
+		case "SET":
+			value, err := e.setValue(action.value)
+			if err != nil {
+				return nil, err
+			}
+			if err := setPath(result, action.path.parts, copyValue(value)); err != nil {
+				return nil, err
+			}
+
+		case "REMOVE":
+			removePath(result, action.path.parts)
+
+		case "ADD":
+			operand, _, err := e.operand(action.value)
+			if err != nil {
+				return nil, err
+			}
+			current, ok := resolvePath(result, action.path.parts)
+			value, err := addValues(current, ok, operand)
+			if err != nil {
+				return nil, err
+			}
+			if err := setPath(result, action.path.parts, value); err != nil {
+				return nil, err
+			}
+
+		case "DELETE":
+			operand, _, err := e.operand(action.value)
+			if err != nil {
+				return nil, err
+			}
+			current, ok := resolvePath(result, action.path.parts)
+			if !ok {
+				continue
+			}
+			value, err := deleteFromSet(current, operand)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				removePath(result, action.path.parts)
+				continue
+			}
+			if err := setPath(result, action.path.parts, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (e evaluator) setValue(n node) (types.AttributeValue, error) {
+	switch v := n.(type) {
+	case arithmeticNode:
+		left, err := e.setValue(v.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.setValue(v.right)
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic(v.op, left, right)
+
+	case funcNode:
+		switch v.name {
+		case "if_not_exists":
+			if len(v.args) != 2 {
+				return nil, validationError("Invalid number of arguments for function if_not_exists")
+			}
+			if current, ok, err := e.operand(v.args[0]); err != nil || ok {
+				return current, err
+			}
+			return e.setValue(v.args[1])
+
+		case "list_append":
+			if len(v.args) != 2 {
+				return nil, validationError("Invalid number of arguments for function list_append")
+			}
+			left, err := e.setValue(v.args[0])
+			if err != nil {
+				return nil, err
+			}
+			right, err := e.setValue(v.args[1])
+			if err != nil {
+				return nil, err
+			}
+			l1, ok1 := left.(*types.AttributeValueMemberL)
+			l2, ok2 := right.(*types.AttributeValueMemberL)
+			if !ok1 || !ok2 {
+				return nil, validationError("Incorrect operand type for operator or function; operator or function: list_append")
+			}
+			values := append(append([]types.AttributeValue{}, l1.Value...), l2.Value...)
+			return &types.AttributeValueMemberL{Value: values}, nil
+		}
+
+		return nil, validationError(fmt.Sprintf("Invalid function name in SET: %s", v.name))
+	}
+
+	value, ok, err := e.operand(n)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, validationError("The provided expression refers to an attribute that does not exist in the item")
+	}
+
+	return value, nil
+}
+
+func arithmetic(op string, left, right types.AttributeValue) (types.AttributeValue, error) {
+	l, ok1 := left.(*types.AttributeValueMemberN)
+	r, ok2 := right.(*types.AttributeValueMemberN)
+	if !ok1 || !ok2 {
+		return nil, validationError(fmt.Sprintf("Incorrect operand type for operator or function; operator: %s", op))
+	}
+
+	ln, ok1 := parseNumber(l.Value)
+	rn, ok2 := parseNumber(r.Value)
+	if !ok1 || !ok2 {
+		return nil, validationError("Invalid number value")
+	}
+
+	if op == "+" {
+		return &types.AttributeValueMemberN{Value: formatNumber(ln.Add(ln, rn))}, nil
+	}
+
+	return &types.AttributeValueMemberN{Value: formatNumber(ln.Sub(ln, rn))}, nil
+}
+
+func addValues(current types.AttributeValue, exists bool, operand types.AttributeValue) (types.AttributeValue, error) {
+	if !exists {
+		return copyValue(operand), nil
+	}
+
+	switch c := current.(type) {
+	case *types.AttributeValueMemberN:
+		return arithmetic("+", c, operand)
+
+	case *types.AttributeValueMemberSS:
+		o, ok := operand.(*types.AttributeValueMemberSS)
+		if !ok {
+			break
+		}
+		values := append([]string{}, c.Value...)
+		set := stringSet(c.Value)
+		for _, v := range o.Value {
+			if _, ok := set[keyString(&types.AttributeValueMemberS{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		return &types.AttributeValueMemberSS{Value: values}, nil
+
+	case *types.AttributeValueMemberNS:
+		o, ok := operand.(*types.AttributeValueMemberNS)
+		if !ok {
+			break
+		}
+		values := append([]string{}, c.Value...)
+		set := numberSet(c.Value)
+		for _, v := range o.Value {
+			if _, ok := set[keyString(&types.AttributeValueMemberN{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		return &types.AttributeValueMemberNS{Value: values}, nil
+
+	case *types.AttributeValueMemberBS:
+		o, ok := operand.(*types.AttributeValueMemberBS)
+		if !ok {
+			break
+		}
+		values := append([][]byte{}, c.Value...)
+		set := binarySet(c.Value)
+		for _, v := range o.Value {
+			if _, ok := set[keyString(&types.AttributeValueMemberB{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		return &types.AttributeValueMemberBS{Value: values}, nil
+	}
+
+	return nil, validationError("Incorrect operand type for operator or function; operator: ADD")
+}
+
+// set 이 비면 nil 반환 (attribute 삭제)
+func deleteFromSet(current, operand types.AttributeValue) (types.AttributeValue, error) {
+	switch c := current.(type) {
+	case *types.AttributeValueMemberSS:
+		o, ok := operand.(*types.AttributeValueMemberSS)
+		if !ok {
+			break
+		}
+		remove := stringSet(o.Value)
+		var values []string
+		for _, v := range c.Value {
+			if _, ok := remove[keyString(&types.AttributeValueMemberS{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberSS{Value: values}, nil
+
+	case *types.AttributeValueMemberNS:
+		o, ok := operand.(*types.AttributeValueMemberNS)
+		if !ok {
+			break
+		}
+		remove := numberSet(o.Value)
+		var values []string
+		for _, v := range c.Value {
+			if _, ok := remove[keyString(&types.AttributeValueMemberN{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberNS{Value: values}, nil
+
+	case *types.AttributeValueMemberBS:
+		o, ok := operand.(*types.AttributeValueMemberBS)
+		if !ok {
+			break
+		}
+		remove := binarySet(o.Value)
+		var values [][]byte
+		for _, v := range c.Value {
+			if _, ok := remove[keyString(&types.AttributeValueMemberB{Value: v})]; !ok {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberBS{Value: values}, nil
+	}
+
+	return nil, validationError("Incorrect operand type for operator or function; operator: DELETE")
+}
+
+func setPath(item map[string]types.AttributeValue, parts []pathPart, value types.AttributeValue) error {
+	if len(parts) == 1 {
+		item[parts[0].name] = value
+		return nil
+	}
+
+	parent, ok := resolvePath(item, parts[:len(parts)-1])
+	if !ok {
+		return validationError("The document path provided in the update expression is invalid for update")
+	}
+
+	last := parts[len(parts)-1]
+	switch v := parent.(type) {
+	case *types.AttributeValueMemberM:
+		if last.isIndex {
+			break
+		}
+		v.Value[last.name] = value
+		return nil
+
+	case *types.AttributeValueMemberL:
+		if !last.isIndex {
+			break
+		}
+		if last.index >= len(v.Value) {
+			v.Value = append(v.Value, value)
+			return nil
+		}
+		v.Value[last.index] = value
+		return nil
+	}
+
+	return validationError("The document path provided in the update expression is invalid for update")
+}
+
+func removePath(item map[string]types.AttributeValue, parts []pathPart) {
+	if len(parts) == 1 {
+		delete(item, parts[0].name)
+		return
+	}
+
+	parent, ok := resolvePath(item, parts[:len(parts)-1])
+	if !ok {
+		return
+	}
+
+	last := parts[len(parts)-1]
+	switch v := parent.(type) {
+	case *types.AttributeValueMemberM:
+		delete(v.Value, last.name)
+	case *types.AttributeValueMemberL:
+		if last.isIndex && last.index < len(v.Value) {
+			v.Value = append(v.Value[:last.index], v.Value[last.index+1:]...)
+		}
+	}
+}
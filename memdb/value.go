@@ -0,0 +1,280 @@
+package memdb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/big"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// key attribute 를 map key 로 쓰기 위한 문자열
+func keyString(av types.AttributeValue) string {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S:" + v.Value
+	case *types.AttributeValueMemberN:
+		if n, ok := parseNumber(v.Value); ok {
+			return "N:" + n.RatString()
+		}
+		return "N:" + v.Value
+	case *types.AttributeValueMemberB:
+		return "B:" + base64.StdEncoding.EncodeToString(v.Value)
+	}
+
+	return ""
+}
+
+func scalarType(av types.AttributeValue) types.ScalarAttributeType {
+	switch av.(type) {
+	case *types.AttributeValueMemberS:
+		return types.ScalarAttributeTypeS
+	case *types.AttributeValueMemberN:
+		return types.ScalarAttributeTypeN
+	case *types.AttributeValueMemberB:
+		return types.ScalarAttributeTypeB
+	}
+
+	return ""
+}
+
+func parseNumber(s string) (*big.Rat, bool) {
+	return new(big.Rat).SetString(s)
+}
+
+func formatNumber(n *big.Rat) string {
+	if n.IsInt() {
+		return n.Num().String()
+	}
+
+	return strings.TrimRight(n.FloatString(38), "0")
+}
+
+// 같은 scalar 타입끼리 비교 (S: byte 순서, N: 숫자, B: byte 순서)
+func compareValues(a, b types.AttributeValue) (int, bool) {
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS:
+		bv, ok := b.(*types.AttributeValueMemberS)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av.Value < bv.Value:
+			return -1, true
+		case av.Value > bv.Value:
+			return 1, true
+		}
+		return 0, true
+
+	case *types.AttributeValueMemberN:
+		bv, ok := b.(*types.AttributeValueMemberN)
+		if !ok {
+			return 0, false
+		}
+		an, ok1 := parseNumber(av.Value)
+		bn, ok2 := parseNumber(bv.Value)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		return an.Cmp(bn), true
+
+	case *types.AttributeValueMemberB:
+		bv, ok := b.(*types.AttributeValueMemberB)
+		if !ok {
+			return 0, false
+		}
+		return bytes.Compare(av.Value, bv.Value), true
+	}
+
+	return 0, false
+}
+
+func equalValues(a, b types.AttributeValue) bool {
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberB:
+		cmp, ok := compareValues(a, b)
+		return ok && cmp == 0
+
+	case *types.AttributeValueMemberBOOL:
+		bv, ok := b.(*types.AttributeValueMemberBOOL)
+		return ok && av.Value == bv.Value
+
+	case *types.AttributeValueMemberNULL:
+		_, ok := b.(*types.AttributeValueMemberNULL)
+		return ok
+
+	case *types.AttributeValueMemberSS:
+		bv, ok := b.(*types.AttributeValueMemberSS)
+		return ok && equalSets(stringSet(av.Value), stringSet(bv.Value))
+
+	case *types.AttributeValueMemberNS:
+		bv, ok := b.(*types.AttributeValueMemberNS)
+		return ok && equalSets(numberSet(av.Value), numberSet(bv.Value))
+
+	case *types.AttributeValueMemberBS:
+		bv, ok := b.(*types.AttributeValueMemberBS)
+		return ok && equalSets(binarySet(av.Value), binarySet(bv.Value))
+
+	case *types.AttributeValueMemberL:
+		bv, ok := b.(*types.AttributeValueMemberL)
+		if !ok || len(av.Value) != len(bv.Value) {
+			return false
+		}
+		for i := range av.Value {
+			if !equalValues(av.Value[i], bv.Value[i]) {
+				return false
+			}
+		}
+		return true
+
+	case *types.AttributeValueMemberM:
+		bv, ok := b.(*types.AttributeValueMemberM)
+		if !ok || len(av.Value) != len(bv.Value) {
+			return false
+		}
+		for k, v := range av.Value {
+			other, ok := bv.Value[k]
+			if !ok || !equalValues(v, other) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func stringSet(values []string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, v := range values {
+		set[keyString(&types.AttributeValueMemberS{Value: v})] = struct{}{}
+	}
+	return set
+}
+
+func numberSet(values []string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, v := range values {
+		set[keyString(&types.AttributeValueMemberN{Value: v})] = struct{}{}
+	}
+	return set
+}
+
+func binarySet(values [][]byte) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, v := range values {
+		set[keyString(&types.AttributeValueMemberB{Value: v})] = struct{}{}
+	}
+	return set
+}
+
+func equalSets(a, b map[string]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// 저장소와 호출자가 같은 slice / map 을 공유하지 않도록 복사
+func copyItem(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+
+	result := make(map[string]types.AttributeValue, len(item))
+	for k, v := range item {
+		result[k] = copyValue(v)
+	}
+	return result
+}
+
+func copyValue(av types.AttributeValue) types.AttributeValue {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return &types.AttributeValueMemberS{Value: v.Value}
+	case *types.AttributeValueMemberN:
+		return &types.AttributeValueMemberN{Value: v.Value}
+	case *types.AttributeValueMemberB:
+		return &types.AttributeValueMemberB{Value: append([]byte{}, v.Value...)}
+	case *types.AttributeValueMemberBOOL:
+		return &types.AttributeValueMemberBOOL{Value: v.Value}
+	case *types.AttributeValueMemberNULL:
+		return &types.AttributeValueMemberNULL{Value: v.Value}
+	case *types.AttributeValueMemberSS:
+		return &types.AttributeValueMemberSS{Value: append([]string{}, v.Value...)}
+	case *types.AttributeValueMemberNS:
+		return &types.AttributeValueMemberNS{Value: append([]string{}, v.Value...)}
+	case *types.AttributeValueMemberBS:
+		values := make([][]byte, len(v.Value))
+		for i, b := range v.Value {
+			values[i] = append([]byte{}, b...)
+		}
+		return &types.AttributeValueMemberBS{Value: values}
+	case *types.AttributeValueMemberL:
+		values := make([]types.AttributeValue, len(v.Value))
+		for i, e := range v.Value {
+			values[i] = copyValue(e)
+		}
+		return &types.AttributeValueMemberL{Value: values}
+	case *types.AttributeValueMemberM:
+		return &types.AttributeValueMemberM{Value: copyItem(v.Value)}
+	}
+
+	return av
+}
+
+// DynamoDB item size 계산 규칙의 근사치
+func itemSize(item map[string]types.AttributeValue) int64 {
+	var size int64
+	for k, v := range item {
+		size += int64(len(k)) + valueSize(v)
+	}
+	return size
+}
+
+func valueSize(av types.AttributeValue) int64 {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return int64(len(v.Value))
+	case *types.AttributeValueMemberN:
+		return int64(len(v.Value)/2 + 1)
+	case *types.AttributeValueMemberB:
+		return int64(len(v.Value))
+	case *types.AttributeValueMemberBOOL, *types.AttributeValueMemberNULL:
+		return 1
+	case *types.AttributeValueMemberSS:
+		var size int64
+		for _, s := range v.Value {
+			size += int64(len(s))
+		}
+		return size
+	case *types.AttributeValueMemberNS:
+		var size int64
+		for _, s := range v.Value {
+			size += int64(len(s)/2 + 1)
+		}
+		return size
+	case *types.AttributeValueMemberBS:
+		var size int64
+		for _, b := range v.Value {
+			size += int64(len(b))
+		}
+		return size
+	case *types.AttributeValueMemberL:
+		size := int64(3)
+		for _, e := range v.Value {
+			size += 1 + valueSize(e)
+		}
+		return size
+	case *types.AttributeValueMemberM:
+		return 3 + itemSize(v.Value)
+	}
+
+	return 0
+}
@@ -0,0 +1,398 @@
+package goddb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Start 에서 이미 있는 테이블을 처리하는 방법
+type ReconcileMode int
+
+const (
+	ReconcileVerify ReconcileMode = iota // 선언과 다르면 TableSchemaError (기본)
+	ReconcileSkip                        // 비교하지 않고 그대로 사용
+	ReconcileUpdate                      // billing mode / 용량 / 새 GSI / TTL 은 적용, 그 외 차이는 TableSchemaError
+	ReconcileFail                        // 확인 없이 CreateTable (이미 있으면 ResourceInUseException)
+)
+
+// SchemaDiff.Field
+const (
+	SchemaFieldHashKey               = "HashKey"
+	SchemaFieldRangeKey              = "RangeKey"
+	SchemaFieldBillingMode           = "BillingMode"
+	SchemaFieldProvisionedThroughput = "ProvisionedThroughput"
+	SchemaFieldTimeToLive            = "TimeToLive"
+	SchemaFieldGlobalIndex           = "GlobalSecondaryIndex." // + index 이름
+	SchemaFieldLocalIndex            = "LocalSecondaryIndex."  // + index 이름
+)
+
+// DDBTableParams 와 실제 테이블이 다른 항목 (없으면 "")
+type SchemaDiff struct {
+	Field     string
+	Expected  string
+	Actual    string
+	Updatable bool // ReconcileUpdate 로 적용 가능
+}
+
+// 이미 있는 테이블을 선언과 비교 (ReconcileUpdate 면 적용 가능한 차이를 UpdateTable 로 반영)
+func (c DDBClient) reconcileTable(ctx context.Context, tableName string, params DDBTableParams, table *types.TableDescription) error {
+
+	if c.reconcile == ReconcileSkip {
+		c.trace(INFO, "DDBClient.Start.Reconcile.Skip", map[string]any{
+			"tableName": tableName,
+		})
+		return nil
+	}
+
+	ttlAttribute := ""
+	if params.TTLAttributeName != "" {
+		output, err := c.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+			TableName: aws.String(tableName),
+		})
+
+		if err != nil {
+			c.trace(ERROR, "DDBClient.Start.Reconcile.DescribeTimeToLive.Error", map[string]any{
+				"tableName": tableName,
+				"error":     err,
+			})
+			return err
+		}
+
+		if ttl := output.TimeToLiveDescription; ttl != nil && ttl.TimeToLiveStatus != types.TimeToLiveStatusDisabled {
+			ttlAttribute = aws.ToString(ttl.AttributeName)
+		}
+	}
+
+	diffs := diffTable(params, table, ttlAttribute)
+	if len(diffs) == 0 {
+		c.trace(INFO, "DDBClient.Start.Reconcile.Match", map[string]any{
+			"tableName": tableName,
+		})
+		return nil
+	}
+
+	schemaErr := &TableSchemaError{TableName: tableName, Diffs: diffs}
+	if c.reconcile != ReconcileUpdate || !schemaErr.Updatable() {
+		c.trace(ERROR, "DDBClient.Start.Reconcile.Mismatch", map[string]any{
+			"tableName": tableName,
+			"diffs":     diffs,
+		})
+		return schemaErr
+	}
+
+	return c.updateTable(ctx, tableName, params, table, diffs)
+}
+
+// 적용 가능한 차이만 있을때 TTL → billing mode → GSI 순서로 반영
+// UpdateTable 은 테이블이 ACTIVE 일때만 가능해서 기본은 Start 마다 하나씩 요청하고 Pending TableSchemaError 반환
+// (WithReconcileWait 면 모두 적용하고 대기)
+func (c DDBClient) updateTable(ctx context.Context, tableName string, params DDBTableParams, table *types.TableDescription, diffs []SchemaDiff) error {
+
+	// 이전 Start 의 UpdateTable (GSI backfill 등) 이 아직 진행중
+	if !tableActive(table) {
+		c.trace(INFO, "DDBClient.Start.Reconcile.Updating", map[string]any{
+			"tableName": tableName,
+			"diffs":     diffs,
+		})
+		return &TableSchemaError{TableName: tableName, Diffs: diffs, Pending: true}
+	}
+
+	billing := false
+	var indexNames []string
+	ttl := false
+
+	for _, diff := range diffs {
+		switch {
+		case diff.Field == SchemaFieldBillingMode, diff.Field == SchemaFieldProvisionedThroughput:
+			billing = true
+		case strings.HasPrefix(diff.Field, SchemaFieldGlobalIndex):
+			indexNames = append(indexNames, strings.TrimPrefix(diff.Field, SchemaFieldGlobalIndex))
+		case diff.Field == SchemaFieldTimeToLive:
+			ttl = true
+		}
+	}
+
+	// TTL 은 UpdateTable 과 별개
+	if ttl {
+		if err := c.enableTimeToLive(ctx, tableName, params.TTLAttributeName); err != nil {
+			return err
+		}
+	}
+
+	// UpdateTable 로 반영할 차이 (ACTIVE 가 되기 전까지는 pending)
+	var tableDiffs []SchemaDiff
+	for _, diff := range diffs {
+		if diff.Field != SchemaFieldTimeToLive {
+			tableDiffs = append(tableDiffs, diff)
+		}
+	}
+
+	var inputs []*dynamodb.UpdateTableInput
+
+	if billing {
+		input := &dynamodb.UpdateTableInput{
+			TableName:   aws.String(tableName),
+			BillingMode: getBillingMode(params.BillingMode),
+		}
+
+		if !params.BillingMode.IsOnDemand {
+			input.ProvisionedThroughput = getProvisionedThroughput(params.BillingMode.IsProvisioned)
+
+			// on-demand → provisioned 는 기존 GSI 용량도 같이 지정
+			if tableBillingMode(table) != types.BillingModeProvisioned {
+				for _, gsi := range table.GlobalSecondaryIndexes {
+					throughput := DDBProvisionedThroughput{}
+					for _, declared := range params.GlobalSecondaryIndexes {
+						if declared.IndexName == aws.ToString(gsi.IndexName) {
+							throughput = declared.Provisioned
+						}
+					}
+
+					input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, types.GlobalSecondaryIndexUpdate{
+						Update: &types.UpdateGlobalSecondaryIndexAction{
+							IndexName:             gsi.IndexName,
+							ProvisionedThroughput: getProvisionedThroughput(getIndexThroughput(params, throughput)),
+						},
+					})
+				}
+			}
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	// GSI 는 UpdateTable 한번에 하나씩만 추가 가능
+	for _, indexName := range indexNames {
+		for _, gsi := range params.GlobalSecondaryIndexes {
+			if gsi.IndexName != indexName {
+				continue
+			}
+
+			indexes, keyAttribute := getGlobalSecondaryIndexes(DDBTableParams{
+				BillingMode:            params.BillingMode,
+				GlobalSecondaryIndexes: []DDBGlobalSecondaryIndex{gsi},
+			}, nil)
+
+			inputs = append(inputs, &dynamodb.UpdateTableInput{
+				TableName:            aws.String(tableName),
+				AttributeDefinitions: keyAttribute,
+				GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+					{
+						Create: &types.CreateGlobalSecondaryIndexAction{
+							IndexName:             indexes[0].IndexName,
+							KeySchema:             indexes[0].KeySchema,
+							Projection:            indexes[0].Projection,
+							ProvisionedThroughput: indexes[0].ProvisionedThroughput,
+						},
+					},
+				},
+			})
+		}
+	}
+
+	for _, input := range inputs {
+		if err := c.applyTableUpdate(ctx, tableName, input); err != nil {
+			return err
+		}
+
+		// 첫 요청만 보내고 반환, 나머지는 다음 Start 에서 적용
+		if !c.reconcileWait {
+			c.trace(INFO, "DDBClient.Start.Reconcile.Update.Pending", map[string]any{
+				"tableName": tableName,
+				"diffs":     tableDiffs,
+			})
+			return &TableSchemaError{TableName: tableName, Diffs: tableDiffs, Pending: true}
+		}
+
+		if err := c.waitTableActive(ctx, tableName); err != nil {
+			return err
+		}
+	}
+
+	c.trace(INFO, "DDBClient.Start.Reconcile.Update.Success", map[string]any{
+		"tableName": tableName,
+		"diffs":     diffs,
+	})
+
+	return nil
+}
+
+func (c DDBClient) applyTableUpdate(ctx context.Context, tableName string, input *dynamodb.UpdateTableInput) error {
+
+	if _, err := c.client.UpdateTable(ctx, input); err != nil {
+		c.trace(ERROR, "DDBClient.Start.Reconcile.UpdateTable.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// 선언과 실제 테이블 비교 (테이블에만 있는 index 는 비교하지 않음)
+func diffTable(params DDBTableParams, table *types.TableDescription, ttlAttribute string) []SchemaDiff {
+	var diffs []SchemaDiff
+
+	diff := func(field, expected, actual string, updatable bool) {
+		if expected != actual {
+			diffs = append(diffs, SchemaDiff{Field: field, Expected: expected, Actual: actual, Updatable: updatable})
+		}
+	}
+
+	attrTypes := map[string]types.ScalarAttributeType{}
+	for _, def := range table.AttributeDefinitions {
+		attrTypes[aws.ToString(def.AttributeName)] = def.AttributeType
+	}
+
+	// key schema
+	names := params.keyAttributes()
+	hashKey, rangeKey := keySchemaNames(table.KeySchema)
+
+	expectedHash, expectedRange := "", ""
+	if params.IsPK {
+		expectedHash = formatKey(names.pk, params.PkAttributeType)
+	}
+	if params.IsSK {
+		expectedRange = formatKey(names.sk, params.SkAttributeType)
+	}
+
+	diff(SchemaFieldHashKey, expectedHash, formatKey(hashKey, attrTypes[hashKey]), false)
+	diff(SchemaFieldRangeKey, expectedRange, formatKey(rangeKey, attrTypes[rangeKey]), false)
+
+	// billing mode
+	billingMode := tableBillingMode(table)
+	diff(SchemaFieldBillingMode, string(getBillingMode(params.BillingMode)), string(billingMode), true)
+
+	if !params.BillingMode.IsOnDemand && billingMode == types.BillingModeProvisioned && table.ProvisionedThroughput != nil {
+		diff(SchemaFieldProvisionedThroughput,
+			formatThroughput(int64(params.BillingMode.IsProvisioned.ReadCapacityUnits), int64(params.BillingMode.IsProvisioned.WriteCapacityUnits)),
+			formatThroughput(aws.ToInt64(table.ProvisionedThroughput.ReadCapacityUnits), aws.ToInt64(table.ProvisionedThroughput.WriteCapacityUnits)),
+			true,
+		)
+	}
+
+	// GSI (없으면 추가 가능, key / projection 이 다르면 다시 만들어야 함)
+	globalIndexes := map[string]types.GlobalSecondaryIndexDescription{}
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		globalIndexes[aws.ToString(gsi.IndexName)] = gsi
+	}
+
+	for _, gsi := range params.GlobalSecondaryIndexes {
+		expected := formatIndex(
+			formatKey(gsi.PkAttributeName, gsi.PkAttributeType),
+			formatKey(gsi.SkAttributeName, gsi.SkAttributeType),
+			getProjection(gsi.ProjectionType, gsi.NonKeyAttributes),
+		)
+
+		actual, ok := globalIndexes[gsi.IndexName]
+		if !ok {
+			diff(SchemaFieldGlobalIndex+gsi.IndexName, expected, "", true)
+			continue
+		}
+
+		indexHash, indexRange := keySchemaNames(actual.KeySchema)
+		diff(SchemaFieldGlobalIndex+gsi.IndexName, expected, formatIndex(
+			formatKey(indexHash, attrTypes[indexHash]),
+			formatKey(indexRange, attrTypes[indexRange]),
+			actual.Projection,
+		), false)
+	}
+
+	// LSI (테이블 생성시에만 정의 가능)
+	localIndexes := map[string]types.LocalSecondaryIndexDescription{}
+	for _, lsi := range table.LocalSecondaryIndexes {
+		localIndexes[aws.ToString(lsi.IndexName)] = lsi
+	}
+
+	for _, lsi := range params.LocalSecondaryIndexes {
+		expected := formatIndex(
+			formatKey(names.pk, params.PkAttributeType),
+			formatKey(lsi.SkAttributeName, lsi.SkAttributeType),
+			getProjection(lsi.ProjectionType, lsi.NonKeyAttributes),
+		)
+
+		actual, ok := localIndexes[lsi.IndexName]
+		if !ok {
+			diff(SchemaFieldLocalIndex+lsi.IndexName, expected, "", false)
+			continue
+		}
+
+		indexHash, indexRange := keySchemaNames(actual.KeySchema)
+		diff(SchemaFieldLocalIndex+lsi.IndexName, expected, formatIndex(
+			formatKey(indexHash, attrTypes[indexHash]),
+			formatKey(indexRange, attrTypes[indexRange]),
+			actual.Projection,
+		), false)
+	}
+
+	// TTL (다른 attribute 로 켜져있으면 끄고 다시 켜야 해서 적용하지 않음)
+	if params.TTLAttributeName != "" {
+		diff(SchemaFieldTimeToLive, params.TTLAttributeName, ttlAttribute, ttlAttribute == "")
+	}
+
+	return diffs
+}
+
+// BillingModeSummary 가 없으면 provisioned
+func tableBillingMode(table *types.TableDescription) types.BillingMode {
+	if table.BillingModeSummary == nil || table.BillingModeSummary.BillingMode == "" {
+		return types.BillingModeProvisioned
+	}
+
+	return table.BillingModeSummary.BillingMode
+}
+
+func keySchemaNames(keySchema []types.KeySchemaElement) (string, string) {
+	var hashKey, rangeKey string
+
+	for _, key := range keySchema {
+		switch key.KeyType {
+		case types.KeyTypeHash:
+			hashKey = aws.ToString(key.AttributeName)
+		case types.KeyTypeRange:
+			rangeKey = aws.ToString(key.AttributeName)
+		}
+	}
+
+	return hashKey, rangeKey
+}
+
+// "PK (S)", key 가 없으면 ""
+func formatKey(name string, attributeType types.ScalarAttributeType) string {
+	if name == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s (%s)", name, attributeType)
+}
+
+// "GSI1PK (S), GSI1SK (S), ALL"
+func formatIndex(hashKey, rangeKey string, projection *types.Projection) string {
+	parts := []string{hashKey}
+	if rangeKey != "" {
+		parts = append(parts, rangeKey)
+	}
+
+	if projection != nil {
+		part := string(projection.ProjectionType)
+		if projection.ProjectionType == types.ProjectionTypeInclude {
+			// 순서는 의미가 없어서 정렬 후 비교
+			nonKeyAttributes := slices.Sorted(slices.Values(projection.NonKeyAttributes))
+			part += " [" + strings.Join(nonKeyAttributes, ", ") + "]"
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatThroughput(read, write int64) string {
+	return fmt.Sprintf("read %d, write %d", read, write)
+}
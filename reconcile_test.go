@@ -0,0 +1,148 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+	"github.com/zkfmapf123/gdrm/memdb"
+)
+
+func Test_DDBReconcile(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	params := client.tables["user_logs_1"]
+
+	describe := func(t *testing.T) *types.TableDescription {
+		output, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		return output.Table
+	}
+
+	t.Run("1. 선언이 같으면 그대로 사용 (verify)", func(t *testing.T) {
+		assert.NoError(t, client.Start(ctx, true))
+	})
+
+	t.Run("2. key schema 가 다르면 TableSchemaError", func(t *testing.T) {
+		changed := params
+		changed.SkAttributeType = types.ScalarAttributeTypeN
+		changed.BillingMode = DDBBillingMode{IsProvisioned: DDBProvisionedThroughput{ReadCapacityUnits: 5, WriteCapacityUnits: 5}}
+
+		err := client.AddTable("user_logs_1", changed).Start(ctx, true)
+
+		var schemaErr *TableSchemaError
+		assert.True(t, errors.As(err, &schemaErr))
+		assert.Eq(t, schemaErr.TableName, "user_logs_1")
+		assert.Eq(t, schemaErr.Diffs, []SchemaDiff{
+			{Field: SchemaFieldRangeKey, Expected: "SK (N)", Actual: "SK (S)"},
+			{Field: SchemaFieldBillingMode, Expected: "PROVISIONED", Actual: "PAY_PER_REQUEST", Updatable: true},
+		})
+
+		// 적용할 수 없는 차이가 있으면 update 도 아무것도 바꾸지 않음
+		err = client.WithReconcileMode(ReconcileUpdate).Start(ctx, true)
+		assert.True(t, errors.As(err, &schemaErr))
+		assert.Eq(t, describe(t).BillingModeSummary.BillingMode, types.BillingModePayPerRequest)
+
+		assert.NoError(t, client.WithReconcileMode(ReconcileSkip).Start(ctx, true))
+	})
+
+	t.Run("3. update 로 billing mode / GSI / TTL 적용", func(t *testing.T) {
+		changed := params
+		changed.BillingMode = DDBBillingMode{IsProvisioned: DDBProvisionedThroughput{ReadCapacityUnits: 5, WriteCapacityUnits: 5}}
+		changed.GlobalSecondaryIndexes = []DDBGlobalSecondaryIndex{
+			{IndexName: "GSI1", PkAttributeName: "Status", PkAttributeType: types.ScalarAttributeTypeS},
+		}
+		changed.TTLAttributeName = "ExpiresAt"
+
+		err := client.AddTable("user_logs_1", changed).WithReconcileMode(ReconcileVerify).Start(ctx, true)
+		assert.Err(t, err)
+
+		// Start 마다 UpdateTable 하나씩 (TTL + billing mode → GSI), 반영중인 차이는 pending 에러
+		err = client.WithReconcileMode(ReconcileUpdate).Start(ctx, true)
+		assert.True(t, errors.Is(err, ErrUpdatePending))
+
+		var schemaErr *TableSchemaError
+		assert.True(t, errors.As(err, &schemaErr))
+		assert.True(t, schemaErr.Pending)
+		assert.Eq(t, schemaErr.Diffs[0].Field, SchemaFieldBillingMode)
+		assert.Eq(t, schemaErr.Diffs[1].Field, SchemaFieldGlobalIndex+"GSI1")
+
+		table := describe(t)
+		assert.Eq(t, table.BillingModeSummary.BillingMode, types.BillingModeProvisioned)
+		assert.Eq(t, aws.ToInt64(table.ProvisionedThroughput.ReadCapacityUnits), int64(5))
+		assert.Len(t, table.GlobalSecondaryIndexes, 0)
+
+		ttl, err := ddbClient.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Eq(t, aws.ToString(ttl.TimeToLiveDescription.AttributeName), "ExpiresAt")
+
+		err = client.Start(ctx, true)
+		assert.True(t, errors.As(err, &schemaErr))
+		assert.Eq(t, schemaErr.Diffs, []SchemaDiff{
+			{Field: SchemaFieldGlobalIndex + "GSI1", Expected: "Status (S), ALL", Updatable: true},
+		})
+
+		table = describe(t)
+		assert.Len(t, table.GlobalSecondaryIndexes, 1)
+		assert.Eq(t, aws.ToString(table.GlobalSecondaryIndexes[0].IndexName), "GSI1")
+
+		// 적용 후에는 verify 통과
+		assert.NoError(t, client.WithReconcileMode(ReconcileVerify).Start(ctx, true))
+	})
+
+	t.Run("4. wait 이면 한번에 적용, INCLUDE attribute 순서는 비교하지 않음", func(t *testing.T) {
+		changed := client.tables["user_logs_1"]
+		changed.GlobalSecondaryIndexes = append(changed.GlobalSecondaryIndexes,
+			DDBGlobalSecondaryIndex{IndexName: "GSI2", PkAttributeName: "Name", PkAttributeType: types.ScalarAttributeTypeS,
+				ProjectionType: types.ProjectionTypeInclude, NonKeyAttributes: []string{"Status", "Amount"}},
+			DDBGlobalSecondaryIndex{IndexName: "GSI3", PkAttributeName: "Role", PkAttributeType: types.ScalarAttributeTypeS},
+		)
+
+		assert.NoError(t, client.AddTable("user_logs_1", changed).WithReconcileMode(ReconcileUpdate).WithReconcileWait(true).Start(ctx, true))
+		assert.Len(t, describe(t).GlobalSecondaryIndexes, 3)
+
+		changed.GlobalSecondaryIndexes[1].NonKeyAttributes = []string{"Amount", "Status"}
+		assert.NoError(t, client.AddTable("user_logs_1", changed).WithReconcileMode(ReconcileVerify).Start(ctx, true))
+
+		client.WithReconcileWait(false)
+	})
+
+	t.Run("5. 테이블이 UPDATING 이면 아무것도 바꾸지 않고 pending", func(t *testing.T) {
+		updating := NewDDB(&updatingDB{DB: ddbClient}).WithReconcileMode(ReconcileUpdate)
+
+		changed := client.tables["user_logs_1"]
+		changed.BillingMode = DDBBillingMode{IsOnDemand: true}
+
+		err := updating.AddTable("user_logs_1", changed).Start(ctx, true)
+		assert.True(t, errors.Is(err, ErrUpdatePending))
+		assert.Eq(t, describe(t).BillingModeSummary.BillingMode, types.BillingModeProvisioned)
+	})
+
+	t.Run("6. fail 이면 이미 있는 테이블 생성 에러", func(t *testing.T) {
+		err := client.WithReconcileMode(ReconcileFail).Start(ctx, true)
+
+		var inUse *types.ResourceInUseException
+		assert.True(t, errors.As(err, &inUse))
+	})
+}
+
+// DescribeTable 이 항상 UPDATING 인 테이블
+type updatingDB struct {
+	*memdb.DB
+}
+
+func (db *updatingDB) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	output, err := db.DB.DescribeTable(ctx, params, optFns...)
+	if err == nil {
+		output.Table.TableStatus = types.TableStatusUpdating
+	}
+	return output, err
+}
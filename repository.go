@@ -0,0 +1,275 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 테이블 하나에 묶인 T 전용 client
+// PK / SK 는 key template 이 있으면 template 으로, 없으면 테이블 key 이름 (기본 PK / SK) 의 dynamodbav 필드에서 가져옴
+// key 필드는 string / 정수 / 실수 / []byte (테이블에 선언된 S / N / B 타입과 맞아야 함)
+type Repository[T any] struct {
+	client    *DDBClient
+	tableName string
+	pkField   []int
+	skField   []int       // 없으면 nil (hash key 만 사용하는 테이블)
+	keys      *entityKeys // key template (없으면 nil)
+}
+
+func NewRepository[T any](c *DDBClient, tableName string) (*Repository[T], error) {
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("repository type %s must be a struct", t)
+	}
+
+	r := &Repository[T]{
+		client:    c,
+		tableName: tableName,
+	}
+
+	names := c.keyAttributes(tableName)
+	for _, field := range reflect.VisibleFields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("dynamodbav"), ",")
+		if !field.IsExported() || name == "" || name != names.pk && name != names.sk {
+			continue
+		}
+
+		if !keyFieldType(field.Type) {
+			return nil, fmt.Errorf("repository type %s: key field %s must be a string, number or []byte, got %s", t, field.Name, field.Type)
+		}
+
+		if name == names.pk {
+			r.pkField = field.Index
+		} else {
+			r.skField = field.Index
+		}
+	}
+
+	keys, err := keyTemplatesOf(t)
+	if err != nil {
+		return nil, err
+	}
+	if keys != nil {
+		if _, ok := keys.template(PrimaryKey); ok {
+			r.keys = keys
+		}
+	}
+
+	if r.pkField == nil && r.keys == nil {
+		return nil, fmt.Errorf("repository type %s has no field tagged dynamodbav:%q or pk key template", t, names.pk)
+	}
+
+	return r, nil
+}
+
+// string / 정수 / 실수 / []byte (keyValue 가 변환할 수 있는 타입)
+func keyFieldType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+
+	return false
+}
+
+// item 의 PK / SK (string key 만, 숫자 / binary key 필드면 에러)
+func (r *Repository[T]) Key(item T) (string, string, error) {
+	pk, sk, err := r.typedKey(item)
+	if err != nil {
+		return "", "", err
+	}
+
+	pkString, ok := pk.(string)
+	if !ok {
+		return "", "", fmt.Errorf("partition key of %s is %T, not a string", reflect.TypeFor[T](), pk)
+	}
+
+	skString, ok := sk.(string)
+	if !ok && sk != nil {
+		return "", "", fmt.Errorf("sort key of %s is %T, not a string", reflect.TypeFor[T](), sk)
+	}
+
+	return pkString, skString, nil
+}
+
+// item 의 PK / SK 값 (필드 타입 그대로, SK 가 없으면 nil)
+func (r *Repository[T]) typedKey(item T) (any, any, error) {
+	v := reflect.ValueOf(item)
+
+	if r.keys != nil {
+		pk, sk, err := r.keys.key(v)
+		return pk, sk, err
+	}
+
+	pk := fieldValue(v, r.pkField)
+	if emptyKey(pk) {
+		return nil, nil, errors.New("empty partition key")
+	}
+
+	return pk, fieldValue(v, r.skField), nil
+}
+
+// 빈 string / []byte (숫자는 0 도 값)
+func emptyKey(value any) bool {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return true
+	}
+
+	return (v.Kind() == reflect.String || v.Kind() == reflect.Slice) && v.Len() == 0
+}
+
+// nil 인 embedded pointer 를 지나면 nil
+func fieldValue(v reflect.Value, index []int) any {
+	if index == nil {
+		return nil
+	}
+
+	field, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return nil
+	}
+
+	return field.Interface()
+}
+
+func (r *Repository[T]) Get(ctx context.Context, pk, sk string) (T, error) {
+	return Get[T](ctx, r.client, r.tableName, pk, sk)
+}
+
+// 숫자 / binary key 로 단건 조회
+func (r *Repository[T]) GetByTypedKey(ctx context.Context, pk, sk any) (T, error) {
+	return GetByTypedKey[T](ctx, r.client, r.tableName, pk, sk)
+}
+
+// 저장, ReturnOldValues 면 덮어쓴 item 반환 (새로 추가했으면 nil)
+func (r *Repository[T]) Put(ctx context.Context, item T, params PutParams) (*T, error) {
+	if _, _, err := r.typedKey(item); err != nil {
+		return nil, err
+	}
+
+	old, err := r.client.Put(ctx, r.tableName, item, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decodeOld(old)
+}
+
+// 없을때만 추가 (이미 있으면 ErrAlreadyExists)
+func (r *Repository[T]) Insert(ctx context.Context, item T) error {
+	_, err := r.Put(ctx, item, PutParams{Mode: PutInsertOnly})
+	return err
+}
+
+// item 의 key 로 부분 수정 후 수정된 item 반환
+func (r *Repository[T]) Update(ctx context.Context, item T, build func(u *DDBUpdate)) (T, error) {
+	var zero T
+
+	pk, sk, err := r.typedKey(item)
+	if err != nil {
+		return zero, err
+	}
+
+	u := r.client.UpdateByTypedKey(ctx, r.tableName, pk, sk)
+	build(u)
+
+	attributes, err := u.ReturnValues(types.ReturnValueAllNew).Execute()
+	if err != nil {
+		return zero, err
+	}
+
+	return decode[T](0, attributes, r.client.decodeOptions(r.tableName))
+}
+
+// item 의 key 로 삭제, ReturnOldValues 면 삭제된 item 반환
+func (r *Repository[T]) Delete(ctx context.Context, item T, params DeleteParams) (*T, error) {
+	pk, sk, err := r.typedKey(item)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := r.client.DeleteByTypedKey(ctx, r.tableName, pk, sk, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decodeOld(old)
+}
+
+func (r *Repository[T]) Query(ctx context.Context, limit int, params RangeParams) ([]T, error) {
+	return Query[T](ctx, r.client, r.tableName, limit, params)
+}
+
+// 테이블 key 이름으로 KeyPrefix 조건 생성
+func (r *Repository[T]) KeyPrefix(item T) (RangeParams, error) {
+	return keyPrefix(item, "", PrimaryKey, SortKey, r.client.keyAttributes(r.tableName))
+}
+
+// 요청 순서대로 찾은 item 과 없는 key 반환 (key 의 TableName 은 무시)
+func (r *Repository[T]) BatchGet(ctx context.Context, keys []DDBKey) ([]T, []DDBKey, error) {
+	tableKeys := make([]DDBKey, len(keys))
+	for i, key := range keys {
+		tableKeys[i] = DDBKey{TableName: r.tableName, PK: key.PK, SK: key.SK}
+	}
+
+	result, err := r.client.FindByKeys(ctx, tableKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]T, 0, len(result.Items))
+	seen := map[DDBKey]struct{}{}
+	for _, key := range tableKeys {
+		item, ok := result.Items[key]
+		if _, dup := seen[key]; !ok || dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		v, err := decode[T](len(items), item, r.client.decodeOptions(r.tableName))
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, v)
+	}
+
+	return items, result.Missing, nil
+}
+
+// 조건 없이 25 개 단위로 저장
+func (r *Repository[T]) BatchPut(ctx context.Context, items []T) error {
+	batch := make([]any, len(items))
+	for i, item := range items {
+		if _, _, err := r.typedKey(item); err != nil {
+			return fmt.Errorf("item[%d]: %w", i, err)
+		}
+		batch[i] = item
+	}
+
+	return r.client.InsertBatch(ctx, r.tableName, batch)
+}
+
+func (r *Repository[T]) decodeOld(old map[string]types.AttributeValue) (*T, error) {
+	if old == nil {
+		return nil, nil
+	}
+
+	v, err := decode[T](0, old, r.client.decodeOptions(r.tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
@@ -0,0 +1,126 @@
+package goddb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+func Test_DDBRepository(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	orders, err := NewRepository[Order](client, "user_logs_1")
+	assert.NoError(t, err)
+
+	t.Run("1. PK 필드 없는 타입", func(t *testing.T) {
+		_, err := NewRepository[struct{ Name string }](client, "user_logs_1")
+		assert.Err(t, err)
+
+		_, err = NewRepository[map[string]any](client, "user_logs_1")
+		assert.Err(t, err)
+	})
+
+	t.Run("2. Insert / Put / Get", func(t *testing.T) {
+		order := Order{PK: "USER#1", SK: "ORDER#001", Amount: 100}
+		assert.NoError(t, orders.Insert(ctx, order))
+		assert.True(t, errors.Is(orders.Insert(ctx, order), ErrAlreadyExists))
+
+		order.Amount = 150
+		old, err := orders.Put(ctx, order, PutParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, old.Amount, 100)
+
+		found, err := orders.Get(ctx, "USER#1", "ORDER#001")
+		assert.NoError(t, err)
+		assert.Eq(t, found, order)
+
+		_, err = orders.Put(ctx, Order{SK: "ORDER#002"}, PutParams{})
+		assert.Err(t, err)
+	})
+
+	t.Run("3. Update / Delete", func(t *testing.T) {
+		updated, err := orders.Update(ctx, Order{PK: "USER#1", SK: "ORDER#001"}, func(u *DDBUpdate) {
+			u.Increment("Amount", 50)
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, updated.Amount, 200)
+
+		old, err := orders.Delete(ctx, updated, DeleteParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, *old, updated)
+
+		_, err = orders.Get(ctx, "USER#1", "ORDER#001")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("4. BatchPut / BatchGet / Query", func(t *testing.T) {
+		var items []Order
+		var keys []DDBKey
+		for i := 1; i <= 30; i++ {
+			items = append(items, Order{PK: "USER#1", SK: fmt.Sprintf("ORDER#%03d", i), Amount: i})
+			keys = append(keys, DDBKey{PK: "USER#1", SK: fmt.Sprintf("ORDER#%03d", 31-i)})
+		}
+		assert.NoError(t, orders.BatchPut(ctx, items))
+
+		missing := DDBKey{PK: "USER#1", SK: "ORDER#999"}
+		found, notFound, err := orders.BatchGet(ctx, append(keys, missing))
+		assert.NoError(t, err)
+		assert.Len(t, found, 30)
+		assert.Eq(t, found[0].Amount, 30) // 요청 순서
+		assert.Eq(t, notFound, []DDBKey{{TableName: "user_logs_1", PK: "USER#1", SK: "ORDER#999"}})
+
+		queried, err := orders.Query(ctx, 0, userOrdersParams)
+		assert.NoError(t, err)
+		assert.Len(t, queried, 30)
+	})
+
+	t.Run("5. 숫자 sort key", func(t *testing.T) {
+		client.AddTable("user_logs_2", DDBTableParams{
+			IsCreate:        true,
+			IsPK:            true,
+			PkAttributeType: types.ScalarAttributeTypeS,
+			IsSK:            true,
+			SkAttributeType: types.ScalarAttributeTypeN,
+			BillingMode:     DDBBillingMode{IsOnDemand: true},
+		})
+		assert.NoError(t, client.Start(ctx, true))
+
+		metrics, err := NewRepository[Metric](client, "user_logs_2")
+		assert.NoError(t, err)
+
+		assert.NoError(t, metrics.Insert(ctx, Metric{PK: "CPU", SK: 0, Value: 1}))
+
+		metric, err := metrics.GetByTypedKey(ctx, "CPU", 0)
+		assert.NoError(t, err)
+		assert.Eq(t, metric.Value, 1.0)
+
+		updated, err := metrics.Update(ctx, Metric{PK: "CPU", SK: 0}, func(u *DDBUpdate) {
+			u.Set("Value", 2)
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, updated, Metric{PK: "CPU", SK: 0, Value: 2})
+
+		// string key 만 반환
+		_, _, err = metrics.Key(metric)
+		assert.Err(t, err)
+
+		old, err := metrics.Delete(ctx, Metric{PK: "CPU", SK: 0}, DeleteParams{ReturnOldValues: true})
+		assert.NoError(t, err)
+		assert.Eq(t, old.Value, 2.0)
+
+		// key 로 쓸 수 없는 필드 타입
+		type boolKey struct {
+			PK string `dynamodbav:"PK"`
+			SK bool   `dynamodbav:"SK"`
+		}
+		_, err = NewRepository[boolKey](client, "user_logs_2")
+		assert.Err(t, err)
+	})
+}
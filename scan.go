@@ -0,0 +1,237 @@
+package goddb
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 조회 - Scan
+type ScanParams struct {
+	IndexName                 string // GSI / LSI 를 scan 할때 index 이름
+	FilterExpression          string // 읽은 뒤에 거르므로 capacity 는 filter 전 기준으로 소비됨
+	ProjectionExpression      string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]types.AttributeValue
+	Segments                  int // 병렬 scan segment 수 (1 이하면 순차 scan)
+	Workers                   int // 동시에 scan 하는 goroutine 수 (0 이면 Segments 만큼)
+}
+
+type scanResult struct {
+	item map[string]types.AttributeValue
+	err  error
+}
+
+// 페이지 단위 scan (cursor 가 "" 이면 처음부터, Segments 는 사용하지 않음)
+func (c DDBClient) Scan(ctx context.Context, tableName string, limit int, cursor string, params ScanParams) (DDBPage, error) {
+
+	c.trace(DEBUG, "DDBClient.Scan", map[string]any{
+		"tableName":  tableName,
+		"limit":      limit,
+		"cursor":     cursor,
+		"expression": params,
+	})
+
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.Scan.Cursor.Error", map[string]any{
+			"tableName": tableName,
+			"cursor":    cursor,
+			"error":     err,
+		})
+		return DDBPage{}, err
+	}
+
+	res, err := c.scan(ctx, tableName, limit, startKey, 0, 0, params)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.Scan.Scan.Error", map[string]any{
+			"tableName":  tableName,
+			"limit":      limit,
+			"expression": params,
+			"error":      err,
+		})
+		return DDBPage{}, err
+	}
+
+	next, err := encodeCursor(res.LastEvaluatedKey)
+	if err != nil {
+		return DDBPage{}, err
+	}
+
+	return DDBPage{
+		Items:  res.Items,
+		Cursor: next,
+	}, nil
+}
+
+// 테이블 전체를 순회, Segments 가 2 이상이면 Workers 개의 goroutine 이 segment 를 나눠서 scan (순서 보장 안됨)
+func (c DDBClient) ScanSeqUseExpression(ctx context.Context, tableName string, pageSize int, params ScanParams) iter.Seq2[map[string]types.AttributeValue, error] {
+
+	return func(yield func(map[string]types.AttributeValue, error) bool) {
+
+		c.trace(DEBUG, "DDBClient.ScanSeqUseExpression", map[string]any{
+			"tableName":  tableName,
+			"pageSize":   pageSize,
+			"expression": params,
+		})
+
+		segments := max(params.Segments, 1)
+		workers := params.Workers
+		if workers <= 0 || workers > segments {
+			workers = segments
+		}
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		scanCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		jobs := make(chan int, segments)
+		for segment := range segments {
+			jobs <- segment
+		}
+		close(jobs)
+
+		results := make(chan scanResult)
+
+		// ctx 취소로 에러를 보내지 못하고 끝난 worker 가 있는지
+		var stopped atomic.Bool
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for segment := range jobs {
+					if err := c.scanSegment(scanCtx, tableName, pageSize, segment, segments, params, results); err != nil {
+						select {
+						case results <- scanResult{err: err}:
+						case <-scanCtx.Done():
+							stopped.Store(true)
+						}
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for result := range results {
+			if result.err != nil {
+				c.trace(ERROR, "DDBClient.ScanSeqUseExpression.Scan.Error", map[string]any{
+					"tableName":  tableName,
+					"expression": params,
+					"error":      result.err,
+				})
+				yield(nil, result.err)
+				return
+			}
+
+			if !yield(result.item, nil) {
+				return
+			}
+		}
+
+		// 바깥 ctx 가 취소되면 worker 가 에러를 못 보내고 끝날 수 있음 (모두 끝까지 scan 했으면 에러 아님)
+		if stopped.Load() {
+			yield(nil, ctx.Err())
+		}
+	}
+}
+
+// ScanSeqUseExpression 결과를 T 로 변환해서 순회
+func ScanSeq[T any](ctx context.Context, c *DDBClient, tableName string, pageSize int, params ScanParams) iter.Seq2[T, error] {
+
+	return func(yield func(T, error) bool) {
+
+		index := 0
+		for item, err := range c.ScanSeqUseExpression(ctx, tableName, pageSize, params) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			// decode 에러는 item 단위로 전달 (계속 순회할지는 호출하는 쪽에서 결정)
+			v, err := decode[T](index, item, c.decodeOptions(tableName))
+			index++
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// segment 하나를 끝까지 scan 하면서 results 로 전달
+func (c DDBClient) scanSegment(ctx context.Context, tableName string, pageSize, segment, totalSegments int, params ScanParams, results chan<- scanResult) error {
+
+	var startKey map[string]types.AttributeValue
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		res, err := c.scan(ctx, tableName, pageSize, startKey, segment, totalSegments, params)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range res.Items {
+			select {
+			case results <- scanResult{item: item}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		startKey = res.LastEvaluatedKey
+		if len(startKey) == 0 {
+			return nil
+		}
+	}
+}
+
+// limit 이 0 이면 Limit 없이 scan, totalSegments 가 1 이하면 segment 없이 scan
+func (c DDBClient) scan(ctx context.Context, tableName string, limit int, startKey map[string]types.AttributeValue, segment, totalSegments int, params ScanParams) (*dynamodb.ScanOutput, error) {
+
+	input := &dynamodb.ScanInput{
+		TableName:                 aws.String(tableName),
+		ExpressionAttributeNames:  params.ExpressionAttributeNames,
+		ExpressionAttributeValues: params.ExpressionAttributeValues,
+		ExclusiveStartKey:         startKey,
+	}
+
+	if limit > 0 {
+		input.Limit = aws.Int32(int32(limit))
+	}
+
+	if params.IndexName != "" {
+		input.IndexName = aws.String(params.IndexName)
+	}
+
+	if params.FilterExpression != "" {
+		input.FilterExpression = aws.String(params.FilterExpression)
+	}
+
+	if params.ProjectionExpression != "" {
+		input.ProjectionExpression = aws.String(params.ProjectionExpression)
+	}
+
+	if totalSegments > 1 {
+		input.Segment = aws.Int32(int32(segment))
+		input.TotalSegments = aws.Int32(int32(totalSegments))
+	}
+
+	return c.client.Scan(ctx, input)
+}
@@ -0,0 +1,123 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+// USER#1 ~ USER#n 파티션에 주문 하나씩 추가
+func scenarioInsertUsers(t *testing.T, tableName string, n int) {
+	var items []any
+	for i := 1; i <= n; i++ {
+		items = append(items, Order{PK: fmt.Sprintf("USER#%d", i), SK: "ORDER#001", Amount: i * 100})
+	}
+
+	assert.NoError(t, client.InsertBatch(ctx, tableName, items))
+}
+
+func Test_DDBScan(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	scenarioInsertUsers(t, "user_logs_1", 20)
+
+	t.Run("1. 페이지 scan (cursor)", func(t *testing.T) {
+		var total int
+		cursor := ""
+		for {
+			page, err := client.Scan(ctx, "user_logs_1", 6, cursor, ScanParams{})
+			assert.NoError(t, err)
+
+			total += len(page.Items)
+			if page.Cursor == "" {
+				break
+			}
+			cursor = page.Cursor
+		}
+
+		assert.Eq(t, total, 20)
+	})
+
+	t.Run("2. FilterExpression / ProjectionExpression", func(t *testing.T) {
+		page, err := client.Scan(ctx, "user_logs_1", 0, "", ScanParams{
+			FilterExpression:     "#amount >= :min",
+			ProjectionExpression: "PK, #amount",
+			ExpressionAttributeNames: map[string]string{
+				"#amount": "Amount",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":min": &types.AttributeValueMemberN{Value: "1500"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 6)
+
+		for _, item := range page.Items {
+			assert.Len(t, item, 2)
+			assert.NotContainsKey(t, item, "SK")
+		}
+	})
+
+	t.Run("3. 병렬 segment scan", func(t *testing.T) {
+		seen := map[string]int{}
+		for order, err := range ScanSeq[Order](ctx, client, "user_logs_1", 3, ScanParams{Segments: 4, Workers: 2}) {
+			assert.NoError(t, err)
+			seen[order.PK]++
+		}
+
+		assert.Len(t, seen, 20)
+		for _, count := range seen {
+			assert.Eq(t, count, 1)
+		}
+	})
+
+	t.Run("4. break / context 취소", func(t *testing.T) {
+		count := 0
+		for _, err := range ScanSeq[Order](ctx, client, "user_logs_1", 3, ScanParams{Segments: 4}) {
+			assert.NoError(t, err)
+			count++
+			if count == 5 {
+				break
+			}
+		}
+		assert.Eq(t, count, 5)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		var lastErr error
+		for _, err := range ScanSeq[Order](cancelCtx, client, "user_logs_1", 3, ScanParams{Segments: 4}) {
+			lastErr = err
+		}
+		assert.True(t, errors.Is(lastErr, context.Canceled))
+
+		// 끝까지 scan 한 뒤에 취소하면 에러 없음
+		cancelCtx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		count = 0
+		for _, err := range ScanSeq[Order](cancelCtx, client, "user_logs_1", 3, ScanParams{}) {
+			assert.NoError(t, err)
+			count++
+			if count == 20 {
+				cancel()
+			}
+		}
+		assert.Eq(t, count, 20)
+	})
+
+	t.Run("5. 없는 테이블", func(t *testing.T) {
+		var lastErr error
+		for _, err := range ScanSeq[Order](ctx, client, "unknown_table", 3, ScanParams{Segments: 2}) {
+			lastErr = err
+		}
+		assert.Err(t, lastErr)
+	})
+}
@@ -0,0 +1,274 @@
+package goddb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// struct tag 로 선언한 테이블 구성
+//
+//	type Order struct {
+//		PK        string `dynamodbav:"PK"`
+//		SK        string `dynamodbav:"SK"`
+//		Status    string `dynamodbav:"Status" gdrm:"gsi=GSI1,hash"`
+//		CreatedAt int64  `dynamodbav:"CreatedAt" gdrm:"gsi=GSI1,range;lsi=LSI1"`
+//		ExpiresAt int64  `dynamodbav:"ExpiresAt" gdrm:"ttl"`
+//	}
+type tableSchema struct {
+	names     keyAttributes // base 의 key 이름 (기본 PK / SK)
+	attrTypes map[string]types.ScalarAttributeType
+
+	gsiNames []string // 선언 순서
+	gsis     map[string]*DDBGlobalSecondaryIndex
+	lsiNames []string
+	lsis     map[string]*DDBLocalSecondaryIndex
+
+	ttl string
+}
+
+// entity struct 의 key / index / TTL 로 테이블 구성 생성 (IsCreate / BillingMode / key 이름은 base 사용)
+func TableParamsFromEntities(base DDBTableParams, entities ...any) (DDBTableParams, error) {
+
+	if len(entities) == 0 {
+		return DDBTableParams{}, fmt.Errorf("table schema needs at least one entity")
+	}
+
+	schema := &tableSchema{
+		names:     base.keyAttributes(),
+		attrTypes: map[string]types.ScalarAttributeType{},
+		gsis:      map[string]*DDBGlobalSecondaryIndex{},
+		lsis:      map[string]*DDBLocalSecondaryIndex{},
+	}
+
+	for _, entity := range entities {
+		t := reflect.TypeOf(entity)
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return DDBTableParams{}, fmt.Errorf("entity type %v must be a struct", t)
+		}
+
+		if err := schema.add(t); err != nil {
+			return DDBTableParams{}, err
+		}
+	}
+
+	pkType, ok := schema.attrTypes[schema.names.pk]
+	if !ok {
+		return DDBTableParams{}, fmt.Errorf("no entity declares %s", schema.names.pk)
+	}
+	skType, isSK := schema.attrTypes[schema.names.sk]
+
+	params := DDBTableParams{
+		IsCreate:         base.IsCreate,
+		BillingMode:      base.BillingMode,
+		IsPK:             true,
+		PkAttributeName:  base.PkAttributeName,
+		PkAttributeType:  pkType,
+		IsSK:             isSK,
+		SkAttributeName:  base.SkAttributeName,
+		SkAttributeType:  skType,
+		TTLAttributeName: schema.ttl,
+	}
+
+	for _, name := range schema.gsiNames {
+		gsi := schema.gsis[name]
+		if gsi.PkAttributeName == "" {
+			return DDBTableParams{}, fmt.Errorf("gsi %s has no hash key", name)
+		}
+		params.GlobalSecondaryIndexes = append(params.GlobalSecondaryIndexes, *gsi)
+	}
+
+	for _, name := range schema.lsiNames {
+		if !isSK {
+			return DDBTableParams{}, fmt.Errorf("lsi %s needs a table with %s", name, schema.names.sk)
+		}
+		params.LocalSecondaryIndexes = append(params.LocalSecondaryIndexes, *schema.lsis[name])
+	}
+
+	return params, nil
+}
+
+// TableParamsFromEntities 결과를 AddTable 로 등록
+func (c *DDBClient) AddTableFromEntities(tableName string, base DDBTableParams, entities ...any) error {
+
+	params, err := TableParamsFromEntities(base, entities...)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.AddTableFromEntities.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return err
+	}
+
+	c.AddTable(tableName, params)
+	return nil
+}
+
+func (s *tableSchema) add(t reflect.Type) error {
+
+	// key template 으로 만드는 key 는 항상 S
+	keys, err := keyTemplatesOf(t)
+	if err != nil {
+		return err
+	}
+	if keys != nil {
+		for _, kt := range keys.templates {
+			if kt.attribute == PrimaryKey || kt.attribute == SortKey {
+				if err := s.attribute(t, s.names.of(kt.attribute), types.ScalarAttributeTypeS); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || len(field.Index) > 1 && !embeddedVisible(t, field.Index) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("dynamodbav"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		tag, hasTag := field.Tag.Lookup("gdrm")
+		isKey := name == s.names.pk || name == s.names.sk
+		if !isKey && (!hasTag || strings.HasPrefix(tag, "children")) {
+			continue
+		}
+
+		attrType, ok := scalarAttributeType(field.Type)
+		if isKey {
+			if !ok {
+				return fmt.Errorf("%s.%s: key must be a string, number or []byte", t, field.Name)
+			}
+			if err := s.attribute(t, name, attrType); err != nil {
+				return err
+			}
+		}
+
+		if !hasTag || strings.HasPrefix(tag, "children") {
+			continue
+		}
+
+		for _, group := range strings.Split(tag, ";") {
+			if err := s.option(t, field, name, attrType, ok, strings.Split(group, ",")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *tableSchema) option(t reflect.Type, field reflect.StructField, name string, attrType types.ScalarAttributeType, scalar bool, options []string) error {
+
+	kind, indexName, _ := strings.Cut(strings.TrimSpace(options[0]), "=")
+
+	if kind == "ttl" {
+		if attrType != types.ScalarAttributeTypeN {
+			return fmt.Errorf("%s.%s: ttl must be a number (epoch seconds)", t, field.Name)
+		}
+		if s.ttl != "" && s.ttl != name {
+			return fmt.Errorf("%s.%s: ttl is already declared on %s", t, field.Name, s.ttl)
+		}
+		s.ttl = name
+		return nil
+	}
+
+	if kind != "gsi" && kind != "lsi" {
+		return fmt.Errorf("%s.%s: unknown gdrm option %q", t, field.Name, options[0])
+	}
+	if indexName == "" {
+		return fmt.Errorf("%s.%s: %s needs an index name", t, field.Name, kind)
+	}
+	if !scalar {
+		return fmt.Errorf("%s.%s: index key must be a string, number or []byte", t, field.Name)
+	}
+	if err := s.attribute(t, name, attrType); err != nil {
+		return err
+	}
+
+	if kind == "lsi" {
+		lsi, ok := s.lsis[indexName]
+		if !ok {
+			lsi = &DDBLocalSecondaryIndex{IndexName: indexName}
+			s.lsis[indexName] = lsi
+			s.lsiNames = append(s.lsiNames, indexName)
+		}
+		if lsi.SkAttributeName != "" && lsi.SkAttributeName != name {
+			return fmt.Errorf("lsi %s range key is declared on both %s and %s", indexName, lsi.SkAttributeName, name)
+		}
+		lsi.SkAttributeName, lsi.SkAttributeType = name, attrType
+		return nil
+	}
+
+	gsi, ok := s.gsis[indexName]
+	if !ok {
+		gsi = &DDBGlobalSecondaryIndex{IndexName: indexName}
+		s.gsis[indexName] = gsi
+		s.gsiNames = append(s.gsiNames, indexName)
+	}
+
+	role := "hash"
+	if len(options) > 1 {
+		role = strings.TrimSpace(options[1])
+	}
+
+	switch role {
+	case "hash":
+		if gsi.PkAttributeName != "" && gsi.PkAttributeName != name {
+			return fmt.Errorf("gsi %s hash key is declared on both %s and %s", indexName, gsi.PkAttributeName, name)
+		}
+		gsi.PkAttributeName, gsi.PkAttributeType = name, attrType
+	case "range":
+		if gsi.SkAttributeName != "" && gsi.SkAttributeName != name {
+			return fmt.Errorf("gsi %s range key is declared on both %s and %s", indexName, gsi.SkAttributeName, name)
+		}
+		gsi.SkAttributeName, gsi.SkAttributeType = name, attrType
+	default:
+		return fmt.Errorf("%s.%s: gsi key must be hash or range, got %q", t, field.Name, role)
+	}
+
+	return nil
+}
+
+// 같은 attribute 를 여러 entity 가 다른 타입으로 선언하면 에러
+func (s *tableSchema) attribute(t reflect.Type, name string, attrType types.ScalarAttributeType) error {
+	if declared, ok := s.attrTypes[name]; ok && declared != attrType {
+		return fmt.Errorf("%s: attribute %s is %s but was declared as %s", t, name, attrType, declared)
+	}
+
+	s.attrTypes[name] = attrType
+	return nil
+}
+
+// Go 타입 -> key attribute 타입
+func scalarAttributeType(t reflect.Type) (types.ScalarAttributeType, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return types.ScalarAttributeTypeS, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return types.ScalarAttributeTypeN, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return types.ScalarAttributeTypeB, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,112 @@
+package goddb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+type ScheduledOrder struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	Status    string `dynamodbav:"Status" gdrm:"gsi=GSI1,hash"`
+	CreatedAt int64  `dynamodbav:"CreatedAt" gdrm:"gsi=GSI1,range;lsi=LSI1"`
+	ExpiresAt int64  `dynamodbav:"ExpiresAt" gdrm:"ttl"`
+}
+
+type ScheduledUser struct {
+	_       struct{} `gdrm:"pk=USER#{UserID},sk=#PROFILE"`
+	UserID  string   `dynamodbav:"-"`
+	Email   string   `dynamodbav:"Email" gdrm:"gsi=GSI2"`
+	Created int64    `dynamodbav:"CreatedAt"`
+}
+
+func Test_DDBTableSchema(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	onDemand := DDBTableParams{IsCreate: true, BillingMode: DDBBillingMode{IsOnDemand: true}}
+
+	t.Run("1. struct tag 로 테이블 구성 생성", func(t *testing.T) {
+		params, err := TableParamsFromEntities(onDemand, ScheduledOrder{}, &ScheduledUser{})
+		assert.NoError(t, err)
+
+		assert.True(t, params.IsCreate && params.IsPK && params.IsSK)
+		assert.Eq(t, params.PkAttributeType, types.ScalarAttributeTypeS)
+		assert.Eq(t, params.TTLAttributeName, "ExpiresAt")
+		assert.Eq(t, params.GlobalSecondaryIndexes, []DDBGlobalSecondaryIndex{
+			{IndexName: "GSI1", PkAttributeName: "Status", PkAttributeType: types.ScalarAttributeTypeS, SkAttributeName: "CreatedAt", SkAttributeType: types.ScalarAttributeTypeN},
+			{IndexName: "GSI2", PkAttributeName: "Email", PkAttributeType: types.ScalarAttributeTypeS},
+		})
+		assert.Eq(t, params.LocalSecondaryIndexes, []DDBLocalSecondaryIndex{
+			{IndexName: "LSI1", SkAttributeName: "CreatedAt", SkAttributeType: types.ScalarAttributeTypeN},
+		})
+	})
+
+	t.Run("2. 잘못된 선언", func(t *testing.T) {
+		_, err := TableParamsFromEntities(onDemand)
+		assert.Err(t, err)
+
+		_, err = TableParamsFromEntities(onDemand, struct{ Name string }{})
+		assert.Err(t, err)
+
+		// 같은 attribute 를 다른 타입으로 선언
+		_, err = TableParamsFromEntities(onDemand, ScheduledOrder{}, struct {
+			PK        string `dynamodbav:"PK"`
+			CreatedAt string `dynamodbav:"CreatedAt" gdrm:"gsi=GSI3"`
+		}{})
+		assert.Err(t, err)
+
+		_, err = TableParamsFromEntities(onDemand, struct {
+			PK        string `dynamodbav:"PK"`
+			ExpiresAt string `dynamodbav:"ExpiresAt" gdrm:"ttl"`
+		}{})
+		assert.Err(t, err)
+
+		// range key 만 있는 GSI, SK 없는 테이블의 LSI
+		_, err = TableParamsFromEntities(onDemand, struct {
+			PK     string `dynamodbav:"PK"`
+			Status string `dynamodbav:"Status" gdrm:"gsi=GSI1,range"`
+		}{})
+		assert.Err(t, err)
+
+		_, err = TableParamsFromEntities(onDemand, struct {
+			PK     string `dynamodbav:"PK"`
+			Status string `dynamodbav:"Status" gdrm:"lsi=LSI1"`
+		}{})
+		assert.Err(t, err)
+	})
+
+	t.Run("3. AddTable 후 생성", func(t *testing.T) {
+		err := client.AddTableFromEntities("user_logs_1", onDemand, ScheduledOrder{}, ScheduledUser{})
+		assert.NoError(t, err)
+		assert.NoError(t, client.Start(ctx, true))
+
+		table, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Eq(t, len(table.Table.GlobalSecondaryIndexes), 2)
+		assert.Eq(t, len(table.Table.LocalSecondaryIndexes), 1)
+
+		ttl, err := ddbClient.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("user_logs_1")})
+		assert.NoError(t, err)
+		assert.Eq(t, ttl.TimeToLiveDescription.TimeToLiveStatus, types.TimeToLiveStatusEnabled)
+		assert.Eq(t, aws.ToString(ttl.TimeToLiveDescription.AttributeName), "ExpiresAt")
+
+		// GSI 로 조회
+		assert.NoError(t, client.Insert(ctx, "user_logs_1", ScheduledUser{UserID: "1", Email: "a@b.c", Created: 1}))
+
+		users, err := Query[ScheduledUser](ctx, client, "user_logs_1", 10, RangeParams{
+			IndexName:              "GSI2",
+			KeyConditionExpression: "Email = :email",
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":email": &types.AttributeValueMemberS{Value: "a@b.c"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Eq(t, users, []ScheduledUser{{UserID: "1", Email: "a@b.c", Created: 1}})
+	})
+}
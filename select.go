@@ -2,7 +2,7 @@ package goddb
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -18,12 +18,19 @@ func (c DDBClient) FindByKey(ctx context.Context, tableName, pk, sk string) (map
 		"sk":        sk,
 	})
 
+	// N / B key 테이블이면 KeyTypeError
+	key, err := c.typedKey(tableName, pk, sk)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.FindByKey.Key.Error", map[string]any{
+			"tableName": tableName,
+			"error":     err,
+		})
+		return nil, err
+	}
+
 	output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: pk},
-			"SK": &types.AttributeValueMemberS{Value: sk},
-		},
+		Key:       key,
 	})
 
 	if err != nil {
@@ -46,16 +53,162 @@ func (c DDBClient) FindByKey(ctx context.Context, tableName, pk, sk string) (map
 			"error":     "item not found",
 		})
 
-		return nil, errors.New("item not found")
+		return nil, ErrNotFound
 	}
 
 	return output.Item, nil
 }
 
+const (
+	BATCH_GET_SIZE = 100
+)
+
+// 다건 조회 key
+type DDBKey struct {
+	TableName string
+	PK        string
+	SK        string // hash key 만 있는 테이블이면 ""
+}
+
+// 다건 조회 결과
+type DDBBatchGetResult struct {
+	Items   map[DDBKey]map[string]types.AttributeValue // 찾은 item
+	Missing []DDBKey                                   // 없는 key (요청 순서)
+}
+
+// 여러 테이블의 key 를 BatchGetItem 100 개 단위로 나눠서 조회
+func (c DDBClient) FindByKeys(ctx context.Context, keys []DDBKey) (DDBBatchGetResult, error) {
+
+	c.trace(DEBUG, "DDBClient.FindByKeys", map[string]any{
+		"keyCount": len(keys),
+	})
+
+	// 중복 key 는 BatchGetItem 에서 에러
+	var unique []DDBKey
+	var batchKeys []batchKey
+	seen := map[DDBKey]struct{}{}
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		batchKey, err := c.newBatchKey(key.TableName, key.PK, key.SK)
+		if err != nil {
+			c.trace(ERROR, "DDBClient.FindByKeys.Key.Error", map[string]any{
+				"tableName": key.TableName,
+				"error":     err,
+			})
+			return DDBBatchGetResult{}, err
+		}
+
+		unique = append(unique, key)
+		batchKeys = append(batchKeys, batchKey)
+	}
+
+	items, err := c.batchGet(ctx, "DDBClient.FindByKeys", batchKeys)
+	if err != nil {
+		return DDBBatchGetResult{}, err
+	}
+
+	result := DDBBatchGetResult{
+		Items: map[DDBKey]map[string]types.AttributeValue{},
+	}
+	for i, key := range unique {
+		if item, ok := items[batchKeys[i].id]; ok {
+			result.Items[key] = item
+		} else {
+			result.Missing = append(result.Missing, key)
+		}
+	}
+
+	c.trace(INFO, "DDBClient.FindByKeys.Success", map[string]any{
+		"keyCount":     len(unique),
+		"missingCount": len(result.Missing),
+	})
+
+	return result, nil
+}
+
+// BatchGetItem 100 개 단위로 조회 (UnprocessedKeys 는 backoff 로 재시도), 응답 item 을 batchKey.id 로 반환
+// keys 는 중복이 없어야 함
+func (c DDBClient) batchGet(ctx context.Context, operation string, keys []batchKey) (map[string]map[string]types.AttributeValue, error) {
+
+	items := map[string]map[string]types.AttributeValue{}
+
+	for i := 0; i < len(keys); i += BATCH_GET_SIZE {
+		batch := keys[i:min(i+BATCH_GET_SIZE, len(keys))]
+
+		requestItems := map[string]types.KeysAndAttributes{}
+		for _, key := range batch {
+			keysAndAttributes := requestItems[key.tableName]
+			keysAndAttributes.Keys = append(keysAndAttributes.Keys, key.key)
+			requestItems[key.tableName] = keysAndAttributes
+		}
+
+		results, err := c.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: requestItems,
+		})
+
+		if err != nil {
+			c.trace(ERROR, operation+".BatchGetItem.Error", map[string]any{
+				"keyCount": len(batch),
+				"error":    err,
+			})
+			return nil, err
+		}
+
+		c.collectBatchGet(items, results.Responses)
+
+		// retry (backoff)
+		started := time.Now()
+		for retryCount := 1; len(results.UnprocessedKeys) > 0 && c.client.retry.allow(retryCount, started); retryCount++ {
+
+			if err := c.client.retry.wait(ctx, retryCount); err != nil {
+				return nil, err
+			}
+
+			results, err = c.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: results.UnprocessedKeys,
+			})
+			if err != nil {
+				c.trace(ERROR, operation+".BatchGetItem.Error", map[string]any{
+					"error":      err,
+					"retryCount": retryCount,
+				})
+				return nil, err
+			}
+
+			c.collectBatchGet(items, results.Responses)
+		}
+
+		if len(results.UnprocessedKeys) > 0 {
+			c.trace(ERROR, operation+".BatchGetItem.UnprocessedKeys", map[string]any{
+				"unprocessedKeys": results.UnprocessedKeys,
+			})
+			return nil, &UnprocessedItemsError{Keys: results.UnprocessedKeys}
+		}
+	}
+
+	return items, nil
+}
+
+// 응답 item 을 요청 key 의 id 로 되돌림
+func (c DDBClient) collectBatchGet(items map[string]map[string]types.AttributeValue, responses map[string][]map[string]types.AttributeValue) {
+	for tableName, tableItems := range responses {
+		for _, item := range tableItems {
+			items[c.itemBatchKey(tableName, item).id] = item
+		}
+	}
+}
+
 // 조회 - Range
 type RangeParams struct {
+	IndexName                 string // GSI / LSI 로 조회할때 index 이름
 	KeyConditionExpression    string
+	ExpressionAttributeNames  map[string]string // #name placeholder (예약어 / 테이블 key 이름)
 	ExpressionAttributeValues map[string]types.AttributeValue
+	Descending                bool // SK 역순 조회 (ScanIndexForward = false)
 }
 
 // Expression 을 사용하여 조회
@@ -67,13 +220,7 @@ func (c DDBClient) FindByKeyUseExpression(ctx context.Context, tableName string,
 		"expression": params,
 	})
 
-	res, err := c.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:                 aws.String(tableName),
-		KeyConditionExpression:    aws.String(params.KeyConditionExpression),
-		ExpressionAttributeValues: params.ExpressionAttributeValues,
-		ScanIndexForward:          aws.Bool(true), // 최신 순
-		Limit:                     aws.Int32(int32(limit)),
-	})
+	res, err := c.query(ctx, tableName, limit, nil, params)
 
 	if err != nil {
 		c.trace(ERROR, "DDBClient.FindByKeyUseRange.Query.Error", map[string]any{
@@ -87,3 +234,137 @@ func (c DDBClient) FindByKeyUseExpression(ctx context.Context, tableName string,
 
 	return res.Items, nil
 }
+
+// 페이지 조회 결과
+type DDBPage struct {
+	Items  []map[string]types.AttributeValue
+	Cursor string // 다음 페이지 cursor (마지막 페이지면 "")
+}
+
+// 페이지 단위 조회 (cursor 가 "" 이면 처음부터)
+func (c DDBClient) FindPageByKeyUseExpression(ctx context.Context, tableName string, limit int, cursor string, params RangeParams) (DDBPage, error) {
+
+	c.trace(DEBUG, "DDBClient.FindPageByKeyUseExpression", map[string]any{
+		"tableName":  tableName,
+		"limit":      limit,
+		"cursor":     cursor,
+		"expression": params,
+	})
+
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.FindPageByKeyUseExpression.Cursor.Error", map[string]any{
+			"tableName": tableName,
+			"cursor":    cursor,
+			"error":     err,
+		})
+		return DDBPage{}, err
+	}
+
+	res, err := c.query(ctx, tableName, limit, startKey, params)
+	if err != nil {
+		c.trace(ERROR, "DDBClient.FindPageByKeyUseExpression.Query.Error", map[string]any{
+			"tableName":  tableName,
+			"limit":      limit,
+			"expression": params,
+			"error":      err,
+		})
+		return DDBPage{}, err
+	}
+
+	next, err := encodeCursor(res.LastEvaluatedKey)
+	if err != nil {
+		return DDBPage{}, err
+	}
+
+	return DDBPage{
+		Items:  res.Items,
+		Cursor: next,
+	}, nil
+}
+
+// 파티션 끝까지 (또는 maxItems 개 까지) 모두 조회, maxItems 가 0 이면 제한 없음
+func (c DDBClient) FindAllByKeyUseExpression(ctx context.Context, tableName string, maxItems int, params RangeParams) ([]map[string]types.AttributeValue, error) {
+
+	c.trace(DEBUG, "DDBClient.FindAllByKeyUseExpression", map[string]any{
+		"tableName":  tableName,
+		"maxItems":   maxItems,
+		"expression": params,
+	})
+
+	items := []map[string]types.AttributeValue{}
+	var startKey map[string]types.AttributeValue
+
+	for {
+		limit := 0
+		if maxItems > 0 {
+			limit = maxItems - len(items)
+		}
+
+		res, err := c.query(ctx, tableName, limit, startKey, params)
+		if err != nil {
+			c.trace(ERROR, "DDBClient.FindAllByKeyUseExpression.Query.Error", map[string]any{
+				"tableName":  tableName,
+				"expression": params,
+				"itemCount":  len(items),
+				"error":      err,
+			})
+			return nil, err
+		}
+
+		items = append(items, res.Items...)
+		startKey = res.LastEvaluatedKey
+
+		if len(startKey) == 0 || (maxItems > 0 && len(items) >= maxItems) {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// limit 이 0 이면 Limit 없이 조회 (1MB 단위 페이지)
+func (c DDBClient) query(ctx context.Context, tableName string, limit int, startKey map[string]types.AttributeValue, params RangeParams) (*dynamodb.QueryOutput, error) {
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		KeyConditionExpression:    aws.String(params.KeyConditionExpression),
+		ExpressionAttributeNames:  params.ExpressionAttributeNames,
+		ExpressionAttributeValues: params.ExpressionAttributeValues,
+		ScanIndexForward:          aws.Bool(!params.Descending),
+		ExclusiveStartKey:         startKey,
+	}
+
+	if limit > 0 {
+		input.Limit = aws.Int32(int32(limit))
+	}
+
+	if params.IndexName != "" {
+		input.IndexName = aws.String(params.IndexName)
+	}
+
+	return c.client.Query(ctx, input)
+}
+
+// FindByKey 결과를 T 로 변환 (없으면 ErrNotFound)
+func Get[T any](ctx context.Context, c *DDBClient, tableName, pk, sk string) (T, error) {
+
+	item, err := c.FindByKey(ctx, tableName, pk, sk)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decode[T](0, item, c.decodeOptions(tableName))
+}
+
+// FindByKeyUseExpression 결과를 T 로 변환
+func Query[T any](ctx context.Context, c *DDBClient, tableName string, limit int, params RangeParams) ([]T, error) {
+
+	items, err := c.FindByKeyUseExpression(ctx, tableName, limit, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAll[T](items, c.decodeOptions(tableName))
+}
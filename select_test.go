@@ -0,0 +1,230 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+	"github.com/zkfmapf123/gdrm/memdb"
+)
+
+// USER#1 파티션에 주문 n 개 추가
+func scenarioInsertOrders(t *testing.T, tableName string, n int) {
+	var items []any
+	for i := 1; i <= n; i++ {
+		items = append(items, Order{PK: "USER#1", SK: fmt.Sprintf("ORDER#%03d", i), Amount: i * 100})
+	}
+
+	assert.NoError(t, client.InsertBatch(ctx, tableName, items))
+}
+
+var userOrdersParams = RangeParams{
+	KeyConditionExpression: "PK = :pk AND begins_with(SK, :sk)",
+	ExpressionAttributeValues: map[string]types.AttributeValue{
+		":pk": &types.AttributeValueMemberS{Value: "USER#1"},
+		":sk": &types.AttributeValueMemberS{Value: "ORDER#"},
+	},
+}
+
+func Test_DDBPagination(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+	scenarioInsertOrders(t, "user_logs_1", 7)
+
+	t.Run("1. cursor 로 페이지 이어서 조회", func(t *testing.T) {
+		var (
+			cursor string
+			sizes  []int
+			orders []Order
+		)
+
+		for {
+			page, err := client.FindPageByKeyUseExpression(ctx, "user_logs_1", 3, cursor, userOrdersParams)
+			assert.NoError(t, err)
+
+			sizes = append(sizes, len(page.Items))
+			orders = append(orders, MarshalMaps[Order](page.Items)...)
+
+			if page.Cursor == "" {
+				break
+			}
+			cursor = page.Cursor
+		}
+
+		assert.Eq(t, sizes, []int{3, 3, 1})
+		assert.Len(t, orders, 7)
+		assert.Eq(t, orders[0].SK, "ORDER#001")
+		assert.Eq(t, orders[6].SK, "ORDER#007")
+	})
+
+	t.Run("2. 잘못된 cursor 에러", func(t *testing.T) {
+		_, err := client.FindPageByKeyUseExpression(ctx, "user_logs_1", 3, "not-a-cursor", userOrdersParams)
+		assert.Err(t, err)
+	})
+
+	t.Run("3. 전체 조회 / 최대 개수 제한", func(t *testing.T) {
+		items, err := client.FindAllByKeyUseExpression(ctx, "user_logs_1", 0, userOrdersParams)
+		assert.NoError(t, err)
+		assert.Len(t, items, 7)
+
+		items, err = client.FindAllByKeyUseExpression(ctx, "user_logs_1", 5, userOrdersParams)
+		assert.NoError(t, err)
+		assert.Len(t, items, 5)
+	})
+
+	t.Run("4. Number / Binary key cursor 변환", func(t *testing.T) {
+		key := map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "ROOM#1"},
+			"SK": &types.AttributeValueMemberN{Value: "1700000000"},
+			"ID": &types.AttributeValueMemberB{Value: []byte{0x01, 0x02}},
+		}
+
+		cursor, err := encodeCursor(key)
+		assert.NoError(t, err)
+
+		decoded, err := decodeCursor(cursor)
+		assert.NoError(t, err)
+		assert.Eq(t, decoded, key)
+	})
+}
+
+// Query 호출 횟수 / 읽은 item 수 확인용
+type countingDB struct {
+	*memdb.DB
+	queryCalls   int
+	queriedItems int
+}
+
+func (db *countingDB) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	db.queryCalls++
+
+	output, err := db.DB.Query(ctx, params, optFns...)
+	if err == nil {
+		db.queriedItems += len(output.Items)
+	}
+	return output, err
+}
+
+func Test_DDBIterator(t *testing.T) {
+
+	ctx = context.Background()
+	db := &countingDB{DB: memdb.New()}
+	client = NewDDB(db)
+
+	scenarioCreateTables(t, "user_logs_1")
+	scenarioInsertOrders(t, "user_logs_1", 7)
+
+	t.Run("1. 전체 순회 (page 단위 조회)", func(t *testing.T) {
+		db.queryCalls = 0
+
+		var orders []Order
+		for order, err := range FindSeq[Order](ctx, client, "user_logs_1", 3, userOrdersParams) {
+			assert.NoError(t, err)
+			orders = append(orders, order)
+		}
+
+		assert.Len(t, orders, 7)
+		assert.Eq(t, orders[6].Amount, 700)
+		assert.Eq(t, db.queryCalls, 3)
+	})
+
+	t.Run("2. break 하면 다음 page 를 조회하지 않음", func(t *testing.T) {
+		db.queryCalls = 0
+
+		count := 0
+		for _, err := range FindSeq[Order](ctx, client, "user_logs_1", 3, userOrdersParams) {
+			assert.NoError(t, err)
+			count++
+			if count == 2 {
+				break
+			}
+		}
+
+		assert.Eq(t, count, 2)
+		assert.Eq(t, db.queryCalls, 1)
+	})
+
+	t.Run("3. context 취소시 에러 후 종료", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var errs []error
+		count := 0
+		for _, err := range FindSeq[Order](cancelCtx, client, "user_logs_1", 3, userOrdersParams) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			count++
+			cancel()
+		}
+
+		assert.Eq(t, count, 1)
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.Is(errs[0], context.Canceled))
+	})
+
+	t.Run("4. 조회 에러 전달", func(t *testing.T) {
+		for _, err := range FindSeq[Order](ctx, client, "unknown_table", 3, userOrdersParams) {
+			assert.Err(t, err)
+		}
+	})
+}
+
+func Test_DDBFindByKeys(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1", "user_logs_2")
+	scenarioInsertOrders(t, "user_logs_1", 120)
+	assert.NoError(t, client.Insert(ctx, "user_logs_2", Message{PK: "USER#1", SK: "#PROFILE", Name: "leedonggyu"}))
+
+	t.Run("1. 여러 테이블 / 100 개 초과 조회", func(t *testing.T) {
+		var keys []DDBKey
+		for i := 1; i <= 120; i++ {
+			keys = append(keys, DDBKey{TableName: "user_logs_1", PK: "USER#1", SK: fmt.Sprintf("ORDER#%03d", i)})
+		}
+		keys = append(keys, DDBKey{TableName: "user_logs_2", PK: "USER#1", SK: "#PROFILE"})
+
+		result, err := client.FindByKeys(ctx, keys)
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 121)
+		assert.Len(t, result.Missing, 0)
+
+		profile := MarshalMap[Message](result.Items[DDBKey{TableName: "user_logs_2", PK: "USER#1", SK: "#PROFILE"}])
+		assert.Eq(t, profile.Name, "leedonggyu")
+	})
+
+	t.Run("2. 없는 key / 중복 key", func(t *testing.T) {
+		found := DDBKey{TableName: "user_logs_1", PK: "USER#1", SK: "ORDER#001"}
+		missing := DDBKey{TableName: "user_logs_1", PK: "USER#2", SK: "ORDER#001"}
+
+		result, err := client.FindByKeys(ctx, []DDBKey{found, missing, found})
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Eq(t, result.Missing, []DDBKey{missing})
+	})
+
+	t.Run("3. UnprocessedKeys 재시도", func(t *testing.T) {
+		db := &throttledDB{DB: ddbClient, unprocessedKeys: 2}
+		retryClient := NewDDB(db).WithRetryPolicy(fastRetryPolicy)
+
+		key := DDBKey{TableName: "user_logs_1", PK: "USER#1", SK: "ORDER#001"}
+
+		result, err := retryClient.FindByKeys(ctx, []DDBKey{key})
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+
+		db.unprocessedKeys = 10
+		_, err = retryClient.FindByKeys(ctx, []DDBKey{key})
+		assert.Err(t, err)
+	})
+}
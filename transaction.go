@@ -0,0 +1,363 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	TRANSACTION_SIZE = 100
+)
+
+// 조건식
+type ConditionParams struct {
+	ConditionExpression       string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]types.AttributeValue
+}
+
+// 수정식 (+ 조건식)
+type UpdateParams struct {
+	UpdateExpression          string
+	ConditionExpression       string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]types.AttributeValue
+}
+
+// 트랜잭션 (TransactWriteItems)
+type DDBTransaction struct {
+	client     DDBClient
+	items      []types.TransactWriteItem
+	operations []transactionOperation
+	err        error
+}
+
+type transactionOperation struct {
+	operation string
+	tableName string
+}
+
+// 트랜잭션 내 작업별 실패 사유
+type TransactionOperationError struct {
+	Index     int    // 추가한 순서
+	Operation string // Put / Update / Delete / ConditionCheck
+	TableName string
+	Code      string // ConditionalCheckFailed, TransactionConflict ...
+	Message   string
+	Item      map[string]types.AttributeValue // 조건 실패시 기존 item
+}
+
+func (e TransactionOperationError) Error() string {
+	return fmt.Sprintf("%s[%d] %s: %s %s", e.Operation, e.Index, e.TableName, e.Code, e.Message)
+}
+
+// TransactionCanceledException 을 작업별로 풀어낸 에러
+type TransactionCanceledError struct {
+	Operations []TransactionOperationError
+	Err        error
+}
+
+func (e *TransactionCanceledError) Error() string {
+	messages := make([]string, 0, len(e.Operations))
+	for _, op := range e.Operations {
+		messages = append(messages, op.Error())
+	}
+
+	return "transaction canceled: " + strings.Join(messages, ", ")
+}
+
+func (e *TransactionCanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (c DDBClient) Transaction() *DDBTransaction {
+	return &DDBTransaction{
+		client: c,
+	}
+}
+
+func (tx *DDBTransaction) Put(tableName string, item any, condition ConditionParams) *DDBTransaction {
+
+	marshalItem, err := tx.client.encodeEntity(tableName, item)
+	if err != nil {
+		tx.err = errors.Join(tx.err, err)
+		return tx
+	}
+
+	put := &types.Put{
+		TableName: aws.String(tableName),
+		Item:      marshalItem,
+	}
+
+	if condition.ConditionExpression != "" {
+		put.ConditionExpression = aws.String(condition.ConditionExpression)
+		put.ExpressionAttributeNames = condition.ExpressionAttributeNames
+		put.ExpressionAttributeValues = condition.ExpressionAttributeValues
+		put.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+
+	return tx.add("Put", tableName, types.TransactWriteItem{Put: put})
+}
+
+func (tx *DDBTransaction) Update(tableName, pk, sk string, params UpdateParams) *DDBTransaction {
+	return tx.UpdateByTypedKey(tableName, pk, sk, params)
+}
+
+// 타입이 있는 key 로 Update (숫자 / binary key 테이블)
+func (tx *DDBTransaction) UpdateByTypedKey(tableName string, pk, sk any, params UpdateParams) *DDBTransaction {
+
+	key, err := tx.client.typedKey(tableName, pk, sk)
+	if err != nil {
+		tx.err = errors.Join(tx.err, err)
+		return tx
+	}
+
+	update := &types.Update{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		UpdateExpression:          aws.String(params.UpdateExpression),
+		ExpressionAttributeNames:  params.ExpressionAttributeNames,
+		ExpressionAttributeValues: params.ExpressionAttributeValues,
+	}
+
+	if params.ConditionExpression != "" {
+		update.ConditionExpression = aws.String(params.ConditionExpression)
+		update.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+
+	return tx.add("Update", tableName, types.TransactWriteItem{Update: update})
+}
+
+func (tx *DDBTransaction) Delete(tableName, pk, sk string, condition ConditionParams) *DDBTransaction {
+	return tx.DeleteByTypedKey(tableName, pk, sk, condition)
+}
+
+// 타입이 있는 key 로 Delete (숫자 / binary key 테이블)
+func (tx *DDBTransaction) DeleteByTypedKey(tableName string, pk, sk any, condition ConditionParams) *DDBTransaction {
+
+	key, err := tx.client.typedKey(tableName, pk, sk)
+	if err != nil {
+		tx.err = errors.Join(tx.err, err)
+		return tx
+	}
+
+	del := &types.Delete{
+		TableName: aws.String(tableName),
+		Key:       key,
+	}
+
+	if condition.ConditionExpression != "" {
+		del.ConditionExpression = aws.String(condition.ConditionExpression)
+		del.ExpressionAttributeNames = condition.ExpressionAttributeNames
+		del.ExpressionAttributeValues = condition.ExpressionAttributeValues
+		del.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+
+	return tx.add("Delete", tableName, types.TransactWriteItem{Delete: del})
+}
+
+// 다른 item 의 상태를 조건으로 거는 작업 (쓰기 없음)
+func (tx *DDBTransaction) ConditionCheck(tableName, pk, sk string, condition ConditionParams) *DDBTransaction {
+	return tx.ConditionCheckByTypedKey(tableName, pk, sk, condition)
+}
+
+// 타입이 있는 key 로 ConditionCheck (숫자 / binary key 테이블)
+func (tx *DDBTransaction) ConditionCheckByTypedKey(tableName string, pk, sk any, condition ConditionParams) *DDBTransaction {
+
+	key, err := tx.client.typedKey(tableName, pk, sk)
+	if err != nil {
+		tx.err = errors.Join(tx.err, err)
+		return tx
+	}
+
+	return tx.add("ConditionCheck", tableName, types.TransactWriteItem{
+		ConditionCheck: &types.ConditionCheck{
+			TableName:                           aws.String(tableName),
+			Key:                                 key,
+			ConditionExpression:                 aws.String(condition.ConditionExpression),
+			ExpressionAttributeNames:            condition.ExpressionAttributeNames,
+			ExpressionAttributeValues:           condition.ExpressionAttributeValues,
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		},
+	})
+}
+
+func (tx *DDBTransaction) add(operation, tableName string, item types.TransactWriteItem) *DDBTransaction {
+	tx.items = append(tx.items, item)
+	tx.operations = append(tx.operations, transactionOperation{
+		operation: operation,
+		tableName: tableName,
+	})
+
+	return tx
+}
+
+func (tx *DDBTransaction) Execute(ctx context.Context) error {
+
+	c := tx.client
+
+	c.trace(DEBUG, "DDBTransaction.Execute", map[string]any{
+		"itemCount": len(tx.items),
+	})
+
+	if tx.err != nil {
+		c.trace(ERROR, "DDBTransaction.Execute.MarshalMap.Error", map[string]any{
+			"error": tx.err,
+		})
+		return tx.err
+	}
+
+	if len(tx.items) == 0 {
+		return errors.New("transaction has no items")
+	}
+
+	if len(tx.items) > TRANSACTION_SIZE {
+		err := fmt.Errorf("transaction has %d items, limit is %d", len(tx.items), TRANSACTION_SIZE)
+		c.trace(ERROR, "DDBTransaction.Execute.Size.Error", map[string]any{
+			"itemCount": len(tx.items),
+			"error":     err,
+		})
+		return err
+	}
+
+	_, err := c.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: tx.items,
+	})
+
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			err = tx.canceledError(canceled)
+		}
+
+		c.trace(ERROR, "DDBTransaction.Execute.TransactWriteItems.Error", map[string]any{
+			"itemCount": len(tx.items),
+			"error":     err,
+		})
+		return err
+	}
+
+	c.trace(INFO, "DDBTransaction.Execute.Success", map[string]any{
+		"itemCount": len(tx.items),
+	})
+
+	return nil
+}
+
+// CancellationReasons 는 요청 순서와 같음, 실패한 작업(Code != None)만 남김
+func (tx *DDBTransaction) canceledError(canceled *types.TransactionCanceledException) error {
+	result := &TransactionCanceledError{
+		Err: canceled,
+	}
+
+	for i, reason := range canceled.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "" || code == "None" || i >= len(tx.operations) {
+			continue
+		}
+
+		result.Operations = append(result.Operations, TransactionOperationError{
+			Index:     i,
+			Operation: tx.operations[i].operation,
+			TableName: tx.operations[i].tableName,
+			Code:      code,
+			Message:   aws.ToString(reason.Message),
+			Item:      reason.Item,
+		})
+	}
+
+	return result
+}
+
+// 트랜잭션 조회 (TransactGetItems)
+type DDBTransactGet struct {
+	client DDBClient
+	items  []types.TransactGetItem
+	err    error // key 타입 에러 (Execute 에서 반환)
+}
+
+func (c DDBClient) TransactGet() *DDBTransactGet {
+	return &DDBTransactGet{
+		client: c,
+	}
+}
+
+func (tx *DDBTransactGet) Get(tableName, pk, sk string) *DDBTransactGet {
+	return tx.GetByTypedKey(tableName, pk, sk)
+}
+
+// 타입이 있는 key 로 Get (숫자 / binary key 테이블)
+func (tx *DDBTransactGet) GetByTypedKey(tableName string, pk, sk any) *DDBTransactGet {
+
+	key, err := tx.client.typedKey(tableName, pk, sk)
+	if err != nil {
+		tx.err = errors.Join(tx.err, err)
+		return tx
+	}
+
+	tx.items = append(tx.items, types.TransactGetItem{
+		Get: &types.Get{
+			TableName: aws.String(tableName),
+			Key:       key,
+		},
+	})
+
+	return tx
+}
+
+// 요청 순서대로 반환, 없는 item 은 nil
+func (tx *DDBTransactGet) Execute(ctx context.Context) ([]map[string]types.AttributeValue, error) {
+
+	c := tx.client
+
+	c.trace(DEBUG, "DDBTransactGet.Execute", map[string]any{
+		"itemCount": len(tx.items),
+	})
+
+	if tx.err != nil {
+		c.trace(ERROR, "DDBTransactGet.Execute.Key.Error", map[string]any{
+			"error": tx.err,
+		})
+		return nil, tx.err
+	}
+
+	if len(tx.items) == 0 {
+		return nil, errors.New("transaction has no items")
+	}
+
+	if len(tx.items) > TRANSACTION_SIZE {
+		err := fmt.Errorf("transaction has %d items, limit is %d", len(tx.items), TRANSACTION_SIZE)
+		c.trace(ERROR, "DDBTransactGet.Execute.Size.Error", map[string]any{
+			"itemCount": len(tx.items),
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	output, err := c.client.TransactGetItems(ctx, &dynamodb.TransactGetItemsInput{
+		TransactItems: tx.items,
+	})
+
+	if err != nil {
+		c.trace(ERROR, "DDBTransactGet.Execute.TransactGetItems.Error", map[string]any{
+			"itemCount": len(tx.items),
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	items := make([]map[string]types.AttributeValue, len(tx.items))
+	for i, response := range output.Responses {
+		if i < len(items) {
+			items[i] = response.Item
+		}
+	}
+
+	return items, nil
+}
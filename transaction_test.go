@@ -0,0 +1,116 @@
+package goddb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+func Test_DDBTransaction(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1", "user_logs_2")
+
+	t.Run("1. 양방향 팀 멤버십 동시 추가", func(t *testing.T) {
+		err := client.Transaction().
+			Put("user_logs_1", Message{PK: "TEAM#DEV", SK: "USER#1", Name: "tom"}, ConditionParams{
+				ConditionExpression: "attribute_not_exists(PK)",
+			}).
+			Put("user_logs_1", Message{PK: "USER#1", SK: "TEAM#DEV", Name: "tom"}, ConditionParams{}).
+			Put("user_logs_2", Message{PK: "TEAM#DEV", SK: "#META", Name: "dev", Age: 1}, ConditionParams{}).
+			Execute(ctx)
+		assert.NoError(t, err)
+
+		_, err = client.FindByKey(ctx, "user_logs_1", "USER#1", "TEAM#DEV")
+		assert.NoError(t, err)
+	})
+
+	t.Run("2. 조건 실패시 전체 취소 + 작업별 에러", func(t *testing.T) {
+		err := client.Transaction().
+			Put("user_logs_1", Message{PK: "TEAM#DEV", SK: "USER#2", Name: "jerry"}, ConditionParams{}).
+			Put("user_logs_1", Message{PK: "TEAM#DEV", SK: "USER#1", Name: "tom"}, ConditionParams{
+				ConditionExpression: "attribute_not_exists(PK)",
+			}).
+			Execute(ctx)
+
+		var canceledErr *TransactionCanceledError
+		assert.True(t, errors.As(err, &canceledErr))
+		assert.Len(t, canceledErr.Operations, 1)
+		assert.Eq(t, canceledErr.Operations[0].Index, 1)
+		assert.Eq(t, canceledErr.Operations[0].Operation, "Put")
+		assert.Eq(t, canceledErr.Operations[0].Code, "ConditionalCheckFailed")
+		assert.NotNil(t, canceledErr.Operations[0].Item)
+
+		var canceled *types.TransactionCanceledException
+		assert.True(t, errors.As(err, &canceled))
+
+		// 첫번째 Put 도 반영되지 않음
+		_, err = client.FindByKey(ctx, "user_logs_1", "TEAM#DEV", "USER#2")
+		assert.Err(t, err)
+	})
+
+	t.Run("3. Update / ConditionCheck / Delete", func(t *testing.T) {
+		err := client.Transaction().
+			ConditionCheck("user_logs_1", "TEAM#DEV", "USER#1", ConditionParams{
+				ConditionExpression: "attribute_exists(PK)",
+			}).
+			Update("user_logs_2", "TEAM#DEV", "#META", UpdateParams{
+				UpdateExpression: "SET Age = Age + :n",
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":n": &types.AttributeValueMemberN{Value: "1"},
+				},
+			}).
+			Delete("user_logs_1", "USER#1", "TEAM#DEV", ConditionParams{}).
+			Execute(ctx)
+		assert.NoError(t, err)
+
+		item, err := client.FindByKey(ctx, "user_logs_2", "TEAM#DEV", "#META")
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Message](item).Age, 2)
+
+		_, err = client.FindByKey(ctx, "user_logs_1", "USER#1", "TEAM#DEV")
+		assert.Err(t, err)
+	})
+
+	t.Run("4. 100개 초과 에러", func(t *testing.T) {
+		tx := client.Transaction()
+		for i := 0; i <= TRANSACTION_SIZE; i++ {
+			tx.Put("user_logs_1", Message{PK: fmt.Sprintf("USER#%d", i), SK: "#PROFILE"}, ConditionParams{})
+		}
+
+		assert.Err(t, tx.Execute(ctx))
+	})
+}
+
+func Test_DDBTransactGet(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	err := client.InsertBatch(ctx, "user_logs_1", []any{
+		Message{PK: "USER#1", SK: "#PROFILE", Name: "tom", Age: 32},
+		Message{PK: "USER#1", SK: "TEAM#DEV", Name: "tom"},
+	})
+	assert.NoError(t, err)
+
+	t.Run("1. 프로필 + 팀 멤버십 동시 조회 (요청 순서 유지)", func(t *testing.T) {
+		items, err := client.TransactGet().
+			Get("user_logs_1", "USER#1", "TEAM#DEV").
+			Get("user_logs_1", "USER#1", "#NONE").
+			Get("user_logs_1", "USER#1", "#PROFILE").
+			Execute(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 3)
+
+		assert.Eq(t, MarshalMap[Message](items[0]).SK, "TEAM#DEV")
+		assert.Nil(t, items[1])
+		assert.Eq(t, MarshalMap[Message](items[2]).Age, 32)
+	})
+}
@@ -0,0 +1,280 @@
+package goddb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 부분 수정 (UpdateItem)
+// attribute 이름 / 값은 #u0, :u0 ... placeholder 로 자동 치환됨 (Condition 에서는 다른 이름을 사용)
+type DDBUpdate struct {
+	client    DDBClient
+	ctx       context.Context
+	tableName string
+	pk        any
+	sk        any
+
+	set    []string
+	remove []string
+	add    []string
+	delete []string
+
+	names        map[string]string
+	values       map[string]types.AttributeValue
+	placeholders map[string]string // attribute 이름 -> placeholder
+
+	condition    ConditionParams
+	returnValues types.ReturnValue
+	err          error
+}
+
+func (c DDBClient) Update(ctx context.Context, tableName, pk, sk string) *DDBUpdate {
+	return c.UpdateByTypedKey(ctx, tableName, pk, sk)
+}
+
+// 타입이 있는 key 로 부분 수정 (숫자 / binary key 테이블)
+func (c DDBClient) UpdateByTypedKey(ctx context.Context, tableName string, pk, sk any) *DDBUpdate {
+	return &DDBUpdate{
+		client:       c,
+		ctx:          ctx,
+		tableName:    tableName,
+		pk:           pk,
+		sk:           sk,
+		names:        map[string]string{},
+		values:       map[string]types.AttributeValue{},
+		placeholders: map[string]string{},
+	}
+}
+
+// SET name = value (name 은 "Address.City", "Tags[0]" 같은 document path 가능)
+func (u *DDBUpdate) Set(name string, value any) *DDBUpdate {
+	u.set = append(u.set, fmt.Sprintf("%s = %s", u.path(name), u.value(value)))
+	return u
+}
+
+// SET name = if_not_exists(name, value)
+func (u *DDBUpdate) SetIfNotExists(name string, value any) *DDBUpdate {
+	path := u.path(name)
+	u.set = append(u.set, fmt.Sprintf("%s = if_not_exists(%s, %s)", path, path, u.value(value)))
+	return u
+}
+
+// SET name = list_append(name, values) (list 가 없으면 새로 생성)
+func (u *DDBUpdate) Append(name string, values any) *DDBUpdate {
+	path := u.path(name)
+	empty := u.value(&types.AttributeValueMemberL{Value: []types.AttributeValue{}})
+	u.set = append(u.set, fmt.Sprintf("%s = list_append(if_not_exists(%s, %s), %s)", path, path, empty, u.value(values)))
+	return u
+}
+
+// SET name = name + n (attribute 가 없으면 0 부터, 음수면 감소)
+func (u *DDBUpdate) Increment(name string, n any) *DDBUpdate {
+	path := u.path(name)
+	zero := u.value(&types.AttributeValueMemberN{Value: "0"})
+	u.set = append(u.set, fmt.Sprintf("%s = if_not_exists(%s, %s) + %s", path, path, zero, u.value(n)))
+	return u
+}
+
+// REMOVE name
+func (u *DDBUpdate) Remove(names ...string) *DDBUpdate {
+	for _, name := range names {
+		u.remove = append(u.remove, u.path(name))
+	}
+	return u
+}
+
+// ADD name value (number 더하기 또는 set 에 추가, set 은 types.AttributeValueMemberSS 등으로 전달)
+func (u *DDBUpdate) Add(name string, value any) *DDBUpdate {
+	u.add = append(u.add, fmt.Sprintf("%s %s", u.path(name), u.value(value)))
+	return u
+}
+
+// DELETE name value (set 에서 제거)
+func (u *DDBUpdate) Delete(name string, value any) *DDBUpdate {
+	u.delete = append(u.delete, fmt.Sprintf("%s %s", u.path(name), u.value(value)))
+	return u
+}
+
+func (u *DDBUpdate) Condition(condition ConditionParams) *DDBUpdate {
+	u.condition = condition
+	return u
+}
+
+// 반환할 item (ALL_NEW 등), 기본은 NONE
+func (u *DDBUpdate) ReturnValues(returnValues types.ReturnValue) *DDBUpdate {
+	u.returnValues = returnValues
+	return u
+}
+
+func (u *DDBUpdate) path(name string) string {
+	var parts []string
+
+	for _, part := range strings.Split(name, ".") {
+		attribute, index, hasIndex := strings.Cut(part, "[")
+
+		// 빈 attribute 이름은 DynamoDB 에서 ValidationException (Execute 에서 반환)
+		if attribute == "" {
+			u.err = errors.Join(u.err, fmt.Errorf("empty attribute name in path %q", name))
+			return ""
+		}
+
+		placeholder, ok := u.placeholders[attribute]
+		if !ok {
+			placeholder = fmt.Sprintf("#u%d", len(u.placeholders))
+			u.placeholders[attribute] = placeholder
+			u.names[placeholder] = attribute
+		}
+
+		if hasIndex {
+			placeholder += "[" + index
+		}
+		parts = append(parts, placeholder)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func (u *DDBUpdate) value(value any) string {
+	av, ok := value.(types.AttributeValue)
+	if !ok {
+		var err error
+		if av, err = attributevalue.Marshal(value); err != nil {
+			u.err = errors.Join(u.err, err)
+			return ""
+		}
+		if av == nil {
+			u.err = errors.Join(u.err, fmt.Errorf("unsupported value type %T", value))
+			return ""
+		}
+	}
+
+	placeholder := fmt.Sprintf(":u%d", len(u.values))
+	u.values[placeholder] = av
+
+	return placeholder
+}
+
+func (u *DDBUpdate) expression() string {
+	var clauses []string
+
+	for _, clause := range []struct {
+		name    string
+		actions []string
+	}{
+		{"SET", u.set},
+		{"REMOVE", u.remove},
+		{"ADD", u.add},
+		{"DELETE", u.delete},
+	} {
+		if len(clause.actions) > 0 {
+			clauses = append(clauses, clause.name+" "+strings.Join(clause.actions, ", "))
+		}
+	}
+
+	return strings.Join(clauses, " ")
+}
+
+// ReturnValues 를 지정하면 해당 attribute 반환 (MarshalMap[T] 로 변환)
+func (u *DDBUpdate) Execute() (map[string]types.AttributeValue, error) {
+
+	c := u.client
+	expression := u.expression()
+
+	c.trace(DEBUG, "DDBUpdate.Execute", map[string]any{
+		"tableName":  u.tableName,
+		"pk":         u.pk,
+		"sk":         u.sk,
+		"expression": expression,
+	})
+
+	if u.err != nil {
+		c.trace(ERROR, "DDBUpdate.Execute.Build.Error", map[string]any{
+			"tableName": u.tableName,
+			"error":     u.err,
+		})
+		return nil, u.err
+	}
+
+	if expression == "" {
+		return nil, errors.New("update has no actions")
+	}
+
+	// N / B key 테이블이면 KeyTypeError
+	key, err := u.client.typedKey(u.tableName, u.pk, u.sk)
+	if err != nil {
+		c.trace(ERROR, "DDBUpdate.Execute.Key.Error", map[string]any{
+			"tableName": u.tableName,
+			"error":     err,
+		})
+		return nil, err
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:        aws.String(u.tableName),
+		Key:              key,
+		UpdateExpression: aws.String(expression),
+		ReturnValues:     u.returnValues,
+	}
+
+	// builder 상태를 바꾸지 않도록 복사해서 condition 을 합침 (Execute 를 다시 호출해도 같은 요청)
+	names := make(map[string]string, len(u.names)+len(u.condition.ExpressionAttributeNames))
+	for k, v := range u.names {
+		names[k] = v
+	}
+	values := make(map[string]types.AttributeValue, len(u.values)+len(u.condition.ExpressionAttributeValues))
+	for k, v := range u.values {
+		values[k] = v
+	}
+
+	if u.condition.ConditionExpression != "" {
+		input.ConditionExpression = aws.String(u.condition.ConditionExpression)
+		input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+
+		for k, v := range u.condition.ExpressionAttributeNames {
+			if _, ok := names[k]; ok {
+				return nil, fmt.Errorf("condition placeholder %s is already used by the update expression", k)
+			}
+			names[k] = v
+		}
+		for k, v := range u.condition.ExpressionAttributeValues {
+			if _, ok := values[k]; ok {
+				return nil, fmt.Errorf("condition placeholder %s is already used by the update expression", k)
+			}
+			values[k] = v
+		}
+	}
+
+	if len(names) > 0 {
+		input.ExpressionAttributeNames = names
+	}
+	if len(values) > 0 {
+		input.ExpressionAttributeValues = values
+	}
+
+	output, err := c.client.UpdateItem(u.ctx, input)
+	if err != nil {
+		c.trace(ERROR, "DDBUpdate.Execute.UpdateItem.Error", map[string]any{
+			"tableName":  u.tableName,
+			"pk":         u.pk,
+			"sk":         u.sk,
+			"expression": expression,
+			"error":      err,
+		})
+		return nil, err
+	}
+
+	c.trace(INFO, "DDBUpdate.Execute.Success", map[string]any{
+		"tableName": u.tableName,
+		"pk":        u.pk,
+		"sk":        u.sk,
+	})
+
+	return output.Attributes, nil
+}
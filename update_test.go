@@ -0,0 +1,173 @@
+package goddb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gookit/assert"
+)
+
+type Profile struct {
+	PK      string            `dynamodbav:"PK"`
+	SK      string            `dynamodbav:"SK"`
+	Name    string            `dynamodbav:"Name"`
+	Age     int               `dynamodbav:"Age"`
+	Visits  int               `dynamodbav:"Visits"`
+	History []string          `dynamodbav:"History,omitempty"`
+	Tags    []string          `dynamodbav:"Tags,stringset"`
+	Address map[string]string `dynamodbav:"Address"`
+}
+
+func Test_DDBUpdate(t *testing.T) {
+
+	scenarioBeforeHook()
+	defer scenarioAfterHook()
+
+	scenarioCreateTables(t, "user_logs_1")
+
+	assert.NoError(t, client.Insert(ctx, "user_logs_1", Profile{
+		PK:      "USER#1",
+		SK:      "#PROFILE",
+		Name:    "leedonggyu",
+		Age:     30,
+		Tags:    []string{"dev", "ops"},
+		Address: map[string]string{"City": "Seoul"},
+	}))
+
+	t.Run("1. SET / arithmetic / list_append / if_not_exists", func(t *testing.T) {
+		item, err := client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Set("Name", "dk").
+			Set("Address.City", "Busan").
+			Increment("Age", 1).
+			Increment("Visits", 3).
+			Append("History", []string{"login"}).
+			SetIfNotExists("Name", "ignored").
+			ReturnValues(types.ReturnValueAllNew).
+			Execute()
+		assert.Err(t, err) // 같은 attribute(Name) 를 두번 수정할 수 없음
+		assert.Nil(t, item)
+
+		item, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Set("Name", "dk").
+			Set("Address.City", "Busan").
+			Increment("Age", 1).
+			Increment("Visits", 3).
+			Append("History", []string{"login"}).
+			ReturnValues(types.ReturnValueAllNew).
+			Execute()
+		assert.NoError(t, err)
+
+		profile := MarshalMap[Profile](item)
+		assert.Eq(t, profile.Name, "dk")
+		assert.Eq(t, profile.Address["City"], "Busan")
+		assert.Eq(t, profile.Age, 31)
+		assert.Eq(t, profile.Visits, 3)
+		assert.Eq(t, profile.History, []string{"login"})
+
+		item, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Append("History", []string{"logout"}).
+			Increment("Age", -2).
+			ReturnValues(types.ReturnValueUpdatedNew).
+			Execute()
+		assert.NoError(t, err)
+		assert.Len(t, item, 2)
+
+		profile = MarshalMap[Profile](item)
+		assert.Eq(t, profile.History, []string{"login", "logout"})
+		assert.Eq(t, profile.Age, 29)
+	})
+
+	t.Run("2. REMOVE / ADD / DELETE (set)", func(t *testing.T) {
+		item, err := client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Remove("Visits", "History").
+			Add("Tags", &types.AttributeValueMemberSS{Value: []string{"sre"}}).
+			Delete("Tags", &types.AttributeValueMemberSS{Value: []string{"dev"}}).
+			ReturnValues(types.ReturnValueAllNew).
+			Execute()
+		assert.Err(t, err) // ADD / DELETE 도 같은 attribute 를 두번 수정할 수 없음
+		assert.Nil(t, item)
+
+		item, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Remove("Visits", "History").
+			Add("Tags", &types.AttributeValueMemberSS{Value: []string{"sre"}}).
+			ReturnValues(types.ReturnValueAllNew).
+			Execute()
+		assert.NoError(t, err)
+
+		profile := MarshalMap[Profile](item)
+		assert.Eq(t, profile.Visits, 0)
+		assert.Nil(t, profile.History)
+		assert.Len(t, profile.Tags, 3)
+
+		item, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Delete("Tags", &types.AttributeValueMemberSS{Value: []string{"dev", "ops"}}).
+			ReturnValues(types.ReturnValueAllNew).
+			Execute()
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Profile](item).Tags, []string{"sre"})
+	})
+
+	t.Run("3. 조건 실패", func(t *testing.T) {
+		update := client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Set("Name", "tom").
+			Condition(ConditionParams{
+				ConditionExpression: "#name = :name",
+				ExpressionAttributeNames: map[string]string{
+					"#name": "Name",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":name": &types.AttributeValueMemberS{Value: "unknown"},
+				},
+			})
+		_, err := update.Execute()
+
+		var condFailed *types.ConditionalCheckFailedException
+		assert.True(t, errors.As(err, &condFailed))
+
+		// Execute 는 builder 의 placeholder 를 바꾸지 않음
+		assert.Len(t, update.names, 1)
+		assert.Len(t, update.values, 1)
+		_, err = update.Execute()
+		assert.True(t, errors.As(err, &condFailed))
+
+		// 자동 생성 placeholder 와 겹치면 에러
+		_, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").
+			Set("Name", "tom").
+			Condition(ConditionParams{
+				ConditionExpression: "#u0 = :u0",
+				ExpressionAttributeNames: map[string]string{
+					"#u0": "Name",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":u0": &types.AttributeValueMemberS{Value: "dk"},
+				},
+			}).
+			Execute()
+		assert.Err(t, err)
+		assert.False(t, errors.As(err, &condFailed))
+
+		item, err := client.FindByKey(ctx, "user_logs_1", "USER#1", "#PROFILE")
+		assert.NoError(t, err)
+		assert.Eq(t, MarshalMap[Profile](item).Name, "dk")
+	})
+
+	t.Run("4. action 없음 / marshal 에러 / 빈 attribute 이름", func(t *testing.T) {
+		_, err := client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").Execute()
+		assert.Err(t, err)
+
+		_, err = client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").Set("Name", make(chan int)).Execute()
+		assert.Err(t, err)
+
+		// 요청 전에 에러 (ValidationException 이 아님)
+		for _, u := range []*DDBUpdate{
+			client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").Set("", 1),
+			client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").Remove("Address."),
+			client.Update(ctx, "user_logs_1", "USER#1", "#PROFILE").Increment("[0]", 1),
+		} {
+			_, err = u.Execute()
+			assert.Err(t, err)
+			assert.StrContains(t, err.Error(), "empty attribute name")
+		}
+	})
+}